@@ -15,35 +15,43 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/login": {
-            "post": {
-                "description": "Faz login e retorna token JWT",
-                "consumes": [
-                    "application/json"
+        "/bloqueados": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Lista os usuários bloqueados pelo usuário autenticado, dos bloqueios mais recentes para os mais antigos",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "login"
+                    "bloqueios"
                 ],
-                "summary": "Login",
+                "summary": "Buscar Bloqueados",
                 "parameters": [
                     {
-                        "description": "Email e senha do usuário",
-                        "name": "login",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.LoginRequest"
-                        }
+                        "type": "integer",
+                        "description": "Página (começa em 1)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de itens por página (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.LoginResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Usuario"
+                            }
                         }
                     },
                     "400": {
@@ -64,15 +72,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -85,31 +84,91 @@ const docTemplate = `{
                 }
             }
         },
-        "/publicacoes": {
+        "/busca/publicacoes": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Busca publicações do usuário autenticado e seus seguidores",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Busca no título e no conteúdo das publicações que o usuário autenticado pode ver, das mais relevantes\npara as menos. Todas as palavras precisam aparecer; use \"aspas\" para frases exatas e palavra* para\nprefixos. Maiúsculas e acentos não fazem diferença. Cada resultado traz um trecho com as ocorrências\nentre \u003cmark\u003e e \u003c/mark\u003e",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "publicacoes"
+                    "busca"
+                ],
+                "summary": "Buscar Publicações por Texto",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Texto a buscar",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID do autor",
+                        "name": "autor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Publicadas a partir desta data (AAAA-MM-DD)",
+                        "name": "desde",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Publicadas até esta data, inclusive (AAAA-MM-DD)",
+                        "name": "ate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hashtag, com ou sem #",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página (começa em 1)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de itens por página (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Buscar Publicações",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Publicacao"
+                                "$ref": "#/definitions/models.PublicacaoEncontrada"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -123,48 +182,30 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/colecoes": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Cria uma nova publicação",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lista as coleções de publicações salvas do usuário autenticado, em ordem alfabética",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "publicacoes"
-                ],
-                "summary": "Criar Publicação",
-                "parameters": [
-                    {
-                        "description": "Dados da publicação",
-                        "name": "publicacao",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.PublicacaoRequest"
-                        }
-                    }
+                    "salvos"
                 ],
+                "summary": "Buscar Coleções",
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/models.Publicacao"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Colecao"
                             }
                         }
                     },
@@ -177,15 +218,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -196,16 +228,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/publicacoes/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Busca uma publicação por ID",
+                "description": "Cria uma coleção vazia para organizar as publicações salvas. O nome não pode repetir o de outra coleção do usuário",
                 "consumes": [
                     "application/json"
                 ],
@@ -213,23 +243,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "publicacoes"
+                    "salvos"
                 ],
-                "summary": "Buscar Publicação",
+                "summary": "Criar Coleção",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID da publicação",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Nome da coleção",
+                        "name": "colecao",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ColecaoRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Publicacao"
+                            "$ref": "#/definitions/models.Colecao"
                         }
                     },
                     "400": {
@@ -241,8 +273,26 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -260,14 +310,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/colecoes/{id}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Atualiza uma publicação do usuário autenticado",
+                "description": "Troca o nome de uma coleção do usuário autenticado",
                 "consumes": [
                     "application/json"
                 ],
@@ -275,30 +327,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "publicacoes"
+                    "salvos"
                 ],
-                "summary": "Atualizar Publicação",
+                "summary": "Renomear Coleção",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID da publicação",
+                        "description": "ID da coleção",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Dados atualizados da publicação",
-                        "name": "publicacao",
+                        "description": "Novo nome da coleção",
+                        "name": "colecao",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.PublicacaoRequest"
+                            "$ref": "#/definitions/models.ColecaoRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Colecao"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -327,6 +382,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
@@ -353,21 +417,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deleta uma publicação do usuário autenticado",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Apaga uma coleção do usuário autenticado. As publicações dela continuam salvas, fora de coleções",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "publicacoes"
+                    "salvos"
                 ],
-                "summary": "Deletar Publicação",
+                "summary": "Deletar Coleção",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID da publicação",
+                        "description": "ID da coleção",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -416,36 +477,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/publicacoes/{id}/curtir": {
-            "post": {
+        "/conversas": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Curte ou descurte uma publicação",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lista as conversas diretas do usuário autenticado, das com mensagens mais recentes para as mais antigas,\ncom a última mensagem e quantas ele ainda não leu",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "publicacoes"
+                    "mensagens"
                 ],
-                "summary": "Curtir Publicação",
+                "summary": "Buscar Conversas",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID da publicação",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Página (começa em 1)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de itens por página (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ConversaDireta"
+                            }
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -475,16 +544,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/usuarios": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Lista usuários, opcionalmente filtrando por nome ou nick",
+                "description": "Cria uma conversa direta do usuário autenticado com os participantes informados. Com um participante a conversa\né entre duas pessoas e, se já existir, ela é retornada com status 200. Grupos têm até 10 pessoas contando o criador.\nQuem ativou mensagensApenasSeguidos só pode ser incluído por quem ele segue",
                 "consumes": [
                     "application/json"
                 ],
@@ -492,70 +559,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "usuarios"
+                    "mensagens"
                 ],
-                "summary": "Listar Usuários",
+                "summary": "Criar Conversa",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Nome ou nick do usuário",
-                        "name": "usuario",
-                        "in": "query"
+                        "description": "Participantes e nome do grupo",
+                        "name": "conversa",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ConversaDiretaRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Usuario"
-                            }
+                            "$ref": "#/definitions/models.ConversaDireta"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ConversaDireta"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            },
-            "post": {
-                "description": "Cria um usuário",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "usuarios"
-                ],
-                "summary": "Criar Usuário",
-                "parameters": [
-                    {
-                        "description": "Dados do usuário",
-                        "name": "usuario",
-                        "in": "body",
-                        "required": true,
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/models.CreateUsuarioRequest"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/models.Usuario"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -584,46 +643,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/usuarios/alterar_senha": {
-            "post": {
+        "/conversas/nao-lidas": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Altera a senha do usuário autenticado",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Conta as mensagens diretas não lidas do usuário autenticado e em quantas conversas elas estão",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "usuarios"
-                ],
-                "summary": "Alterar Senha",
-                "parameters": [
-                    {
-                        "description": "Senhas atual e nova",
-                        "name": "alterarSenha",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.AlterarSenha"
-                        }
-                    }
+                    "mensagens"
                 ],
+                "summary": "Contar Mensagens Não Lidas",
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.ContagemMensagens"
                         }
                     },
                     "401": {
@@ -635,15 +674,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -656,28 +686,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/usuarios/{id}": {
+        "/conversas/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Busca um usuário por ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retorna uma conversa direta do usuário autenticado",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "usuarios"
+                    "mensagens"
                 ],
-                "summary": "Buscar Usuário",
+                "summary": "Buscar Conversa Direta",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID do usuário",
+                        "description": "ID da conversa",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -687,7 +714,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Usuario"
+                            "$ref": "#/definitions/models.ConversaDireta"
                         }
                     },
                     "400": {
@@ -699,6 +726,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -719,39 +755,27 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Atualiza os dados de um usuário",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Apaga a conversa só para o usuário autenticado. As mensagens até agora deixam de aparecer para ele\ne a conversa volta para a lista quando chegar uma mensagem nova",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "usuarios"
+                    "mensagens"
                 ],
-                "summary": "Atualizar Usuário",
+                "summary": "Apagar Conversa",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID do usuário",
+                        "description": "ID da conversa",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Dados atualizados do usuário",
-                        "name": "usuario",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateUsuarioRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -776,15 +800,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -794,15 +809,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -813,28 +819,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/conversas/{id}/lida": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deleta um usuário",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Marca como lidas todas as mensagens da conversa para o usuário autenticado",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "usuarios"
+                    "mensagens"
                 ],
-                "summary": "Deletar Usuário",
+                "summary": "Marcar Conversa Lida",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID do usuário",
+                        "description": "ID da conversa",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -862,15 +867,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -892,41 +888,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/usuarios/{id}/seguidores": {
+        "/conversas/{id}/mensagens": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Busca os seguidores de um usuário",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lista as mensagens da conversa, das mais recentes para as mais antigas. Para buscar as anteriores,\nenvie o proximoCursor da resposta no parâmetro antes",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "usuarios"
+                    "mensagens"
                 ],
-                "summary": "Buscar Seguidores",
+                "summary": "Buscar Mensagens",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID do usuário",
+                        "description": "ID da conversa",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cursor: busca as mensagens anteriores a esta",
+                        "name": "antes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de mensagens (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Usuario"
-                            }
+                            "$ref": "#/definitions/models.PaginaMensagens"
                         }
                     },
                     "400": {
@@ -938,57 +940,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/usuarios/{id}/seguindo": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Busca os usuários que um usuário está seguindo",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "usuarios"
-                ],
-                "summary": "Buscar Seguindo",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID do usuário",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Usuario"
-                            }
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1006,16 +968,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/usuarios/{id}/seguir": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Segue ou deixa de seguir um usuário",
+                "description": "Envia uma mensagem na conversa. Em conversas entre duas pessoas, quem ativou mensagensApenasSeguidos\nsó recebe de quem ele segue. Não é possível enviar em conversas, inclusive grupos, com alguém que\nbloqueou o usuário ou foi bloqueado por ele",
                 "consumes": [
                     "application/json"
                 ],
@@ -1023,21 +983,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "usuarios"
+                    "mensagens"
                 ],
-                "summary": "Alternar Seguir Usuário",
+                "summary": "Enviar Mensagem",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID do usuário",
+                        "description": "ID da conversa",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Texto da mensagem",
+                        "name": "mensagem",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MensagemRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Mensagem"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1057,8 +1029,245 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/conversas/{id}/mensagens/{mensagemId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apaga a mensagem só para o usuário autenticado; os demais participantes continuam vendo",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mensagens"
+                ],
+                "summary": "Apagar Mensagem",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da conversa",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID da mensagem",
+                        "name": "mensagemId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/eventos": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stream (Server-Sent Events) com as publicações novas de quem o usuário segue, as mudanças na contagem de reações\ndessas publicações, as notificações e as mensagens diretas novas. Cada evento traz id, event (publicacao, reacoes,\nnotificacao, mensagem ou reiniciar)\ne data em JSON. Para retomar, envie o id do último evento recebido no cabeçalho Last-Event-ID (o EventSource faz isso\nsozinho) ou em ultimoId; se não der para retomar, chega um evento reiniciar e o cliente deve recarregar o feed.\nO token pode ir no parâmetro token para clientes que não enviam cabeçalhos. Seguir, deixar de seguir,\nsilenciar ou bloquear alguém vale para a conexão aberta, sem precisar reconectar",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "eventos"
+                ],
+                "summary": "Acompanhar Eventos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token JWT, quando não for possível enviar o cabeçalho Authorization",
+                        "name": "token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id do último evento recebido",
+                        "name": "ultimoId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Evento"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Faz login e retorna token JWT",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "login"
+                ],
+                "summary": "Login",
+                "parameters": [
+                    {
+                        "description": "Email e senha do usuário",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1069,139 +1278,5896 @@ const docTemplate = `{
                 }
             }
         },
-        "/usuarios/{usuarioId}/publicacoes": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
+        "/midias/{chave}": {
+            "get": {
+                "description": "Entrega um arquivo de mídia. Não usa token: a URL assinada devolvida nos anexos é a autorização",
+                "produces": [
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "tags": [
+                    "anexos"
+                ],
+                "summary": "Baixar Mídia",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chave do arquivo",
+                        "name": "chave",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Momento em que a URL expira (unix)",
+                        "name": "expira",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Assinatura da URL",
+                        "name": "assinatura",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/notificacoes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista as notificações do usuário autenticado, das mais recentes para as mais antigas.\nAções do mesmo tipo sobre o mesmo alvo são agrupadas enquanto a notificação não é lida",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notificacoes"
+                ],
+                "summary": "Buscar Notificações",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Só as não lidas",
+                        "name": "naoLidas",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página (começa em 1)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de itens por página (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Notificacao"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/notificacoes/lidas": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marca como lidas todas as notificações do usuário autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notificacoes"
+                ],
+                "summary": "Marcar Todas as Notificações como Lidas",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/notificacoes/nao-lidas": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Conta as notificações não lidas do usuário autenticado, no total e por tipo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notificacoes"
+                ],
+                "summary": "Contar Notificações Não Lidas",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ContagemNotificacoes"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/notificacoes/preferencias": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Informa, para cada tipo de notificação, se o usuário autenticado quer recebê-la",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notificacoes"
+                ],
+                "summary": "Buscar Preferências de Notificação",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PreferenciasNotificacao"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ativa ou desativa tipos de notificação do usuário autenticado. Só os tipos enviados são alterados.\nTipos: seguir, curtida, reacao, comentario, mencao",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notificacoes"
+                ],
+                "summary": "Atualizar Preferências de Notificação",
+                "parameters": [
+                    {
+                        "description": "Tipo e se deve ser notificado",
+                        "name": "preferencias",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PreferenciasNotificacao"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PreferenciasNotificacao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/notificacoes/{id}/lida": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marca uma notificação do usuário autenticado como lida. Novas ações do mesmo tipo passam a gerar outra notificação",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notificacoes"
+                ],
+                "summary": "Marcar Notificação como Lida",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da notificação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/publicacoes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Busca publicações do usuário autenticado e de quem ele segue. No modo cronologico (padrão), traz\ntambém as republicações, da mais recente para a mais antiga. No modo relevancia, traz as publicações\nrecentes de quem ele segue e de quem essas pessoas seguem, ordenadas pela idade, pelas reações e\ncomentários e por quanto ele interage com o autor. A pontuação é calculada no instante de referência:\nrepita o X-Feed-Referencia da primeira página nas seguintes para que a ordem não mude entre elas",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publicacoes"
+                ],
+                "summary": "Buscar Publicações",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "cronologico ou relevancia",
+                        "name": "modo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Instante da pontuação no modo relevancia (RFC 3339), padrão agora",
+                        "name": "referencia",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página no modo relevancia (começa em 1)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de itens por página no modo relevancia (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Publicacao"
+                            }
+                        },
+                        "headers": {
+                            "X-Feed-Referencia": {
+                                "type": "string",
+                                "description": "Instante usado na pontuação do modo relevancia"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cria uma nova publicação. Informe emRespostaA para responder, citacaoDe para citar outra publicação\ne status rascunho ou agendada (com publicarEm) para não publicar na hora. visibilidade seguidores\nrestringe a publicação aos seguidores; mencionados, aos usuários mencionados no conteúdo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publicacoes"
+                ],
+                "summary": "Criar Publicação",
+                "parameters": [
+                    {
+                        "description": "Dados da publicação",
+                        "name": "publicacao",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PublicacaoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Publicacao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/publicacoes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Busca uma publicação por ID. Se o usuário autenticado não puder vê-la, a resposta é 404,\nsem revelar que ela existe",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publicacoes"
+                ],
+                "summary": "Buscar Publicação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Publicacao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Atualiza uma publicação do usuário autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publicacoes"
+                ],
+                "summary": "Atualizar Publicação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados atualizados da publicação",
+                        "name": "publicacao",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PublicacaoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deleta uma publicação do usuário autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publicacoes"
+                ],
+                "summary": "Deletar Publicação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/publicacoes/{id}/anexos": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Anexa uma imagem (JPEG, PNG ou GIF) a uma publicação do usuário autenticado.\nO tipo é conferido pelo conteúdo, metadados como EXIF são removidos e uma miniatura é gerada",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "anexos"
+                ],
+                "summary": "Enviar Anexo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Imagem",
+                        "name": "arquivo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Anexo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/publicacoes/{id}/anexos/{anexoId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove um anexo de uma publicação do usuário autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "anexos"
+                ],
+                "summary": "Deletar Anexo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID do anexo",
+                        "name": "anexoId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/publicacoes/{id}/comentarios": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Busca os comentários de uma publicação, do mais antigo para o mais novo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comentarios"
+                ],
+                "summary": "Buscar Comentários",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página (começa em 1)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de itens por página (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Comentario"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cria um comentário do usuário autenticado em uma publicação",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comentarios"
+                ],
+                "summary": "Comentar Publicação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados do comentário",
+                        "name": "comentario",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ComentarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Comentario"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/publicacoes/{id}/comentarios/{comentarioId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Atualiza um comentário do usuário autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comentarios"
+                ],
+                "summary": "Atualizar Comentário",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID do comentário",
+                        "name": "comentarioId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados atualizados do comentário",
+                        "name": "comentario",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ComentarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deleta um comentário. Pode ser feito pelo autor do comentário ou pelo autor da publicação",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comentarios"
+                ],
+                "summary": "Deletar Comentário",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID do comentário",
+                        "name": "comentarioId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/publicacoes/{id}/conversa": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Busca uma publicação com a cadeia de publicações que ela responde e a árvore de respostas",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publicacoes"
+                ],
+                "summary": "Buscar Conversa",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantos níveis de respostas trazer (padrão 3, máximo 10)",
+                        "name": "profundidade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Conversa"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/publicacoes/{id}/curtida": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Curte uma publicação, substituindo outra reação que o usuário tenha feito nela. Repetir a chamada\nmantém a curtida sem alterar quando ela foi feita",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publicacoes"
+                ],
+                "summary": "Adicionar Curtida",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EstadoCurtida"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a curtida de uma publicação. Repetir a chamada mantém a publicação sem curtida",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publicacoes"
+                ],
+                "summary": "Remover Curtida",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EstadoCurtida"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/publicacoes/{id}/curtir": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Curte ou descurte uma publicação. Prefira PUT e DELETE em /publicacoes/{id}/curtida, que podem ser repetidos com segurança",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publicacoes"
+                ],
+                "summary": "Curtir Publicação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/publicacoes/{id}/reacao": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Grava a reação do usuário autenticado na publicação, trocando a reação anterior se existir",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reacoes"
+                ],
+                "summary": "Reagir a Publicação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tipo da reação",
+                        "name": "reacao",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReacaoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a reação do usuário autenticado na publicação",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reacoes"
+                ],
+                "summary": "Remover Reação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/publicacoes/{id}/reacoes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista quem reagiu a uma publicação, opcionalmente filtrando por tipo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reacoes"
+                ],
+                "summary": "Buscar Reações",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo da reação",
+                        "name": "tipo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página (começa em 1)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de itens por página (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Reacao"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/publicacoes/{id}/republicar": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compartilha uma publicação no feed de quem segue o usuário autenticado. Republicar de novo não tem efeito.\nRepublicações de contas privadas continuam visíveis só para os seguidores do autor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publicacoes"
+                ],
+                "summary": "Republicar Publicação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a republicação feita pelo usuário autenticado. Para desfazer uma citação, delete a publicação que cita",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publicacoes"
+                ],
+                "summary": "Desfazer Republicação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/publicacoes/{id}/revisoes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Busca as versões de uma publicação, da original até a atual, com o diff de cada edição.\nTambém informa se a publicação mudou depois da reação do usuário autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publicacoes"
+                ],
+                "summary": "Buscar Revisões",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.HistoricoPublicacao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/publicacoes/{id}/salvo": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Salva a publicação para o usuário autenticado, sem avisar o autor. Com colecaoId, a publicação fica\nnaquela coleção; salvar de novo só troca a coleção. O corpo é opcional",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "salvos"
+                ],
+                "summary": "Salvar Publicação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Coleção onde salvar",
+                        "name": "salvo",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.SalvarRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tira a publicação das salvas do usuário autenticado. Repetir a chamada não tem efeito",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "salvos"
+                ],
+                "summary": "Remover Publicação Salva",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da publicação",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/rascunhos": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Busca os rascunhos e as publicações agendadas do usuário autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rascunhos"
+                ],
+                "summary": "Buscar Rascunhos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Página (começa em 1)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de itens por página (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Publicacao"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/rascunhos/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Atualiza texto, status, data de publicação e visibilidade de um rascunho ou agendada do usuário autenticado.\nCom status publicada, o rascunho é publicado na hora",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rascunhos"
+                ],
+                "summary": "Atualizar Rascunho",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do rascunho",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados atualizados do rascunho",
+                        "name": "rascunho",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PublicacaoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Publicacao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/rascunhos/{id}/publicar": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Publica na hora um rascunho ou agendada do usuário autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rascunhos"
+                ],
+                "summary": "Publicar Rascunho",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do rascunho",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Publicacao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/salvos": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista as publicações salvas pelo usuário autenticado, das salvas mais recentemente para as mais antigas.\nInforme colecao para ver só as de uma coleção",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "salvos"
+                ],
+                "summary": "Buscar Salvos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da coleção",
+                        "name": "colecao",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página (começa em 1)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de itens por página (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Publicacao"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/silenciados": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista os usuários silenciados pelo usuário autenticado, dos mais recentes para os mais antigos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bloqueios"
+                ],
+                "summary": "Buscar Silenciados",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Página (começa em 1)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de itens por página (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Usuario"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/solicitacoes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista quem pediu para seguir o usuário autenticado, das solicitações mais antigas para as mais recentes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Buscar Solicitações",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Página (começa em 1)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de itens por página (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Usuario"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/solicitacoes/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Aceita o pedido do usuário da rota para seguir o usuário autenticado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Aceitar Solicitação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de quem pediu para seguir",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Recusa o pedido do usuário da rota para seguir o usuário autenticado. Quem pediu não é avisado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Recusar Solicitação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de quem pediu para seguir",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/trending": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista as tags mais usadas nas publicações recentes. Só entram publicações da janela configurada\n(TAGS_JANELA_HORAS, padrão 24) e cada uso perde metade do peso a cada TAGS_MEIA_VIDA_HORAS (padrão 6)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Tags em Alta",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de tags (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TagEmAlta"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{tag}/publicacoes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Busca as publicações que usam a hashtag, das mais recentes para as mais antigas. A tag pode vir com ou sem #",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Buscar Publicações da Tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página (começa em 1)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de itens por página (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Publicacao"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Busca usuários pelo nome ou nick, aceitando o começo, um trecho ou pequenos erros de digitação\n(a primeira letra precisa estar certa). O nick exato vem primeiro, depois quem o usuário autenticado\nsegue, quem o segue e então os mais parecidos com o termo. Quem tem bloqueio com o usuário não aparece",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Listar Usuários",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nome ou nick do usuário, com ou sem @",
+                        "name": "usuario",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página (começa em 1)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de itens por página (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Usuario"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Cria um usuário",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Criar Usuário",
+                "parameters": [
+                    {
+                        "description": "Dados do usuário",
+                        "name": "usuario",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateUsuarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Usuario"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/alterar_senha": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Altera a senha do usuário autenticado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Alterar Senha",
+                "parameters": [
+                    {
+                        "description": "Senhas atual e nova",
+                        "name": "alterarSenha",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AlterarSenha"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/autocompletar": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sugere usuários cujo nick ou nome começa com o prefixo, para completar @menções. Traz só id, nome,\nnick e avatar, com o nick exato primeiro, depois quem o usuário autenticado segue e quem o segue",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Autocompletar Usuários",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Começo do nick ou do nome, com ou sem @",
+                        "name": "prefixo",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de sugestões (padrão e máximo 20)",
+                        "name": "limite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Usuario"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/sugestoes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Recomenda contas para o usuário autenticado seguir: seguidas por quem ele segue, que já o seguem\ne que publicaram recentemente. As sugestões são recalculadas periodicamente; quem ele passou a\nseguir, bloqueou ou dispensou desde então já não aparece",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Sugestões de Quem Seguir",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Página (começa em 1)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de itens por página (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Sugestao"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/sugestoes/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tira a conta das sugestões do usuário autenticado, inclusive dos próximos cálculos",
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Dispensar Sugestão",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário sugerido",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Busca um usuário por ID. Quem bloqueou o usuário autenticado não é encontrado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Buscar Usuário",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Usuario"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Atualiza os dados de um usuário",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Atualizar Usuário",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados atualizados do usuário",
+                        "name": "usuario",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateUsuarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deleta um usuário",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Deletar Usuário",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Atualiza parcialmente o perfil do usuário autenticado: só os campos enviados são alterados.\nO site é validado (sem esquema, assume https), a data de nascimento usa o formato AAAA-MM-DD\ne só aparece para outros usuários com dataNascimentoPublica. publicacaoFixadaId 0 desafixa\nCom privado = true, novos seguidores precisam ser aceitos; ao voltar a ser pública, as solicitações pendentes são aceitas",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Atualizar Perfil",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos a alterar",
+                        "name": "perfil",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AtualizarPerfilRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Usuario"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/{id}/avatar": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Define o avatar do usuário autenticado. A imagem é recortada no centro e gerada nos tamanhos padrão (48, 128 e 400px)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Enviar Avatar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Imagem",
+                        "name": "arquivo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImagemPerfil"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove o avatar do usuário autenticado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Remover Avatar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/{id}/banner": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Define o banner do perfil do usuário autenticado. A imagem é recortada na proporção 3:1 e gerada em 600x200 e 1500x500",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Enviar Banner",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Imagem",
+                        "name": "arquivo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImagemPerfil"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove o banner do perfil do usuário autenticado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Remover Banner",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/{id}/bloqueio": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bloqueia um usuário: os dois deixam de se seguir e não podem voltar a seguir, curtir, reagir, mencionar ou enviar\nmensagens um ao outro, e as publicações de cada um somem para o outro. Repetir a chamada mantém o bloqueio",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bloqueios"
+                ],
+                "summary": "Bloquear Usuário",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove o bloqueio. Os follows desfeitos ao bloquear não voltam",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bloqueios"
+                ],
+                "summary": "Desbloquear Usuário",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/{id}/relacao": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Informa se o usuário autenticado segue o usuário e é seguido por ele, as solicitações pendentes nos\ndois sentidos, os bloqueios nos dois sentidos, se o autenticado o silenciou e quantos seguidores em\ncomum eles têm. Se o usuário silenciou o autenticado não é informado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Relação com Usuário",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Relacao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/{id}/seguidores": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Busca os seguidores de um usuário. Em contas privadas, só o dono e seus seguidores podem ver a lista",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Buscar Seguidores",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Usuario"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/{id}/seguidores-em-comum": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista quem o usuário autenticado segue e também segue o usuário. Em contas privadas, só o dono e\nseus seguidores podem ver a lista",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Buscar Seguidores em Comum",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página (começa em 1)",
+                        "name": "pagina",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de itens por página (máximo 100)",
+                        "name": "limite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Usuario"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/{id}/seguindo": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Busca os usuários que um usuário está seguindo. Em contas privadas, só o dono e seus seguidores podem ver a lista",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Buscar Seguindo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Usuario"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/{id}/seguir": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Segue um usuário. Se a conta for privada, cria uma solicitação que o dono precisa aceitar e o estado volta com solicitado = true.\nRepetir a chamada mantém o usuário seguido ou a solicitação pendente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Seguir Usuário",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EstadoSeguir"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Segue ou deixa de seguir um usuário. Em contas privadas, seguir cria uma solicitação e deixar de seguir também a cancela.\nPrefira PUT e DELETE em /usuarios/{id}/seguir, que podem ser repetidos com segurança",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Alternar Seguir Usuário",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deixa de seguir um usuário ou cancela a solicitação pendente. Repetir a chamada mantém o usuário não seguido",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usuarios"
+                ],
+                "summary": "Deixar de Seguir Usuário",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EstadoSeguir"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/{id}/silencio": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Esconde do feed do usuário autenticado as publicações e republicações de outro usuário, sem deixar de segui-lo\ne sem que ele saiba. Repetir a chamada mantém o usuário silenciado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bloqueios"
+                ],
+                "summary": "Silenciar Usuário",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Volta a mostrar no feed as publicações do usuário",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bloqueios"
+                ],
+                "summary": "Dessilenciar Usuário",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/usuarios/{usuarioId}/publicacoes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Busca publicações de um usuário específico. A publicação fixada no perfil vem primeiro, com fixada = true.\nSe um dos dois bloqueou o outro, a lista vem vazia. Contas privadas só mostram publicações ao dono e aos seguidores",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publicacoes"
+                ],
+                "summary": "Buscar Publicações do Usuário",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do usuário",
+                        "name": "usuarioId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Publicacao"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.AlterarSenha": {
+            "type": "object",
+            "properties": {
+                "atual": {
+                    "type": "string"
+                },
+                "nova": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Anexo": {
+            "type": "object",
+            "properties": {
+                "CriadoEm": {
+                    "type": "string"
+                },
+                "altura": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "largura": {
+                    "type": "integer"
+                },
+                "publicacaoId": {
+                    "type": "integer"
+                },
+                "tamanho": {
+                    "type": "integer"
+                },
+                "tipo": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "urlMiniatura": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AtorNotificacao": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "$ref": "#/definitions/models.ImagemPerfil"
+                },
+                "nick": {
+                    "type": "string"
+                },
+                "usuarioId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AtualizarPerfilRequest": {
+            "type": "object",
+            "properties": {
+                "bio": {
+                    "type": "string"
+                },
+                "dataNascimento": {
+                    "type": "string"
+                },
+                "dataNascimentoPublica": {
+                    "type": "boolean"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "localizacao": {
+                    "type": "string"
+                },
+                "mensagensApenasSeguidos": {
+                    "type": "boolean"
+                },
+                "nick": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "privado": {
+                    "type": "boolean"
+                },
+                "publicacaoFixadaId": {
+                    "type": "integer"
+                },
+                "site": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Colecao": {
+            "type": "object",
+            "properties": {
+                "criadaEm": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "salvos": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ColecaoRequest": {
+            "type": "object",
+            "properties": {
+                "nome": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Comentario": {
+            "type": "object",
+            "properties": {
+                "CriadoEm": {
+                    "type": "string"
+                },
+                "atualizadoEm": {
+                    "type": "string"
+                },
+                "autorAvatar": {
+                    "$ref": "#/definitions/models.ImagemPerfil"
+                },
+                "autorId": {
+                    "type": "integer"
+                },
+                "autorNick": {
+                    "type": "string"
+                },
+                "conteudo": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "publicacaoId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ComentarioRequest": {
+            "type": "object",
+            "properties": {
+                "conteudo": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ContagemMensagens": {
+            "type": "object",
+            "properties": {
+                "conversas": {
+                    "type": "integer"
+                },
+                "naoLidas": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ContagemNotificacoes": {
+            "type": "object",
+            "properties": {
+                "naoLidas": {
+                    "type": "integer"
+                },
+                "porTipo": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                }
+            }
+        },
+        "models.Conversa": {
+            "type": "object",
+            "properties": {
+                "ancestrais": {
+                    "description": "Ancestrais vai da raiz da conversa até a publicação respondida diretamente",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Publicacao"
+                    }
+                },
+                "publicacao": {
+                    "$ref": "#/definitions/models.Publicacao"
+                },
+                "respostas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RespostaConversa"
+                    }
+                }
+            }
+        },
+        "models.ConversaDireta": {
+            "type": "object",
+            "properties": {
+                "atualizadaEm": {
+                    "type": "string"
+                },
+                "grupo": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "naoLidas": {
+                    "type": "integer"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "participantes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ParticipanteConversa"
+                    }
+                },
+                "ultimaMensagem": {
+                    "$ref": "#/definitions/models.Mensagem"
+                }
+            }
+        },
+        "models.ConversaDiretaRequest": {
+            "type": "object",
+            "properties": {
+                "nome": {
+                    "type": "string"
+                },
+                "participantes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.CreateUsuarioRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "nick": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "senha": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EstadoCurtida": {
+            "type": "object",
+            "properties": {
+                "curtidas": {
+                    "type": "integer"
+                },
+                "curtido": {
+                    "type": "boolean"
+                },
+                "publicacaoId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.EstadoSeguir": {
+            "type": "object",
+            "properties": {
+                "seguidores": {
+                    "type": "integer"
+                },
+                "seguindo": {
+                    "type": "boolean"
+                },
+                "solicitado": {
+                    "type": "boolean"
+                },
+                "usuarioId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Evento": {
+            "type": "object",
+            "properties": {
+                "criadoEm": {
+                    "type": "string"
+                },
+                "dados": {},
+                "id": {
+                    "type": "integer"
+                },
+                "tipo": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HistoricoPublicacao": {
+            "type": "object",
+            "properties": {
+                "edicoes": {
+                    "type": "integer"
+                },
+                "editadaAposMinhaReacao": {
+                    "type": "boolean"
+                },
+                "editadaEm": {
+                    "type": "string"
+                },
+                "minhaReacaoEm": {
+                    "type": "string"
+                },
+                "publicacaoId": {
+                    "type": "integer"
+                },
+                "revisoes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Revisao"
+                    }
+                }
+            }
+        },
+        "models.ImagemPerfil": {
+            "type": "object",
+            "properties": {
+                "grande": {
+                    "type": "string"
+                },
+                "medio": {
+                    "type": "string"
+                },
+                "pequeno": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "senha"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "senha": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Mencao": {
+            "type": "object",
+            "properties": {
+                "fim": {
+                    "type": "integer"
+                },
+                "inicio": {
+                    "type": "integer"
+                },
+                "nick": {
+                    "type": "string"
+                },
+                "usuarioId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Mensagem": {
+            "type": "object",
+            "properties": {
+                "autorId": {
+                    "type": "integer"
+                },
+                "autorNick": {
+                    "type": "string"
+                },
+                "conversaId": {
+                    "type": "integer"
+                },
+                "criadaEm": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "texto": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MensagemRequest": {
+            "type": "object",
+            "properties": {
+                "texto": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Notificacao": {
+            "type": "object",
+            "properties": {
+                "atores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AtorNotificacao"
+                    }
+                },
+                "atualizadaEm": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lida": {
+                    "type": "boolean"
+                },
+                "publicacaoId": {
+                    "type": "integer"
+                },
+                "texto": {
+                    "type": "string"
+                },
+                "tipo": {
+                    "type": "string"
+                },
+                "totalAtores": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PaginaMensagens": {
+            "type": "object",
+            "properties": {
+                "mensagens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Mensagem"
+                    }
+                },
+                "proximoCursor": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ParticipanteConversa": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "$ref": "#/definitions/models.ImagemPerfil"
+                },
+                "nick": {
+                    "type": "string"
+                },
+                "ultimaLidaId": {
+                    "type": "integer"
+                },
+                "usuarioId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PreferenciasNotificacao": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "boolean"
+            }
+        },
+        "models.Publicacao": {
+            "type": "object",
+            "properties": {
+                "CriadaEm": {
+                    "type": "string"
+                },
+                "anexos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Anexo"
+                    }
+                },
+                "autorAvatar": {
+                    "$ref": "#/definitions/models.ImagemPerfil"
+                },
+                "autorId": {
+                    "type": "integer"
+                },
+                "autorNick": {
+                    "type": "string"
+                },
+                "citacao": {
+                    "description": "Citacao é a publicação embutida quando esta é uma citação",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Publicacao"
+                        }
+                    ]
+                },
+                "citacaoDe": {
+                    "type": "integer"
+                },
+                "citacoes": {
+                    "type": "integer"
+                },
+                "comentarios": {
+                    "type": "integer"
+                },
+                "conteudo": {
+                    "type": "string"
+                },
+                "curtidas": {
+                    "type": "integer"
+                },
+                "curtidoPorMim": {
+                    "description": "CurtidoPorMim indica se o usuário autenticado curtiu a publicação",
+                    "type": "boolean"
+                },
+                "edicoes": {
+                    "type": "integer"
+                },
+                "editadaAposMinhaReacao": {
+                    "description": "EditadaAposMinhaReacao indica se a publicação foi editada depois que o usuário autenticado reagiu a ela",
+                    "type": "boolean"
+                },
+                "editadaEm": {
+                    "type": "string"
+                },
+                "emRespostaA": {
+                    "type": "integer"
+                },
+                "fixada": {
+                    "description": "Fixada indica a publicação fixada no topo do perfil do autor",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mencoes": {
+                    "description": "Mencoes são os @nick do conteúdo que correspondem a usuários",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Mencao"
+                    }
+                },
+                "minha": {
+                    "description": "Minha indica se o usuário autenticado é o autor",
+                    "type": "boolean"
+                },
+                "minhaReacao": {
+                    "description": "MinhaReacao é o tipo da reação do usuário autenticado, se houver",
+                    "type": "string"
+                },
+                "publicarEm": {
+                    "type": "string"
+                },
+                "reacoes": {
+                    "description": "Reacoes traz a quantidade de reações por tipo",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "republicacoes": {
+                    "type": "integer"
+                },
+                "republicadaPor": {
+                    "description": "RepublicadaPor é preenchido quando o item do feed é uma republicação",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Republicacao"
+                        }
+                    ]
+                },
+                "republicadoPorMim": {
+                    "description": "RepublicadoPorMim indica se o usuário autenticado republicou a publicação",
+                    "type": "boolean"
+                },
+                "respostas": {
+                    "type": "integer"
+                },
+                "salvo": {
+                    "description": "Salvo indica se o usuário autenticado salvou a publicação",
+                    "type": "boolean"
+                },
+                "sigoAutor": {
+                    "description": "SigoAutor indica se o usuário autenticado segue o autor",
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "Tags são as hashtags do título e do conteúdo, extraídas em Preparar",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "titulo": {
+                    "type": "string"
+                },
+                "visibilidade": {
+                    "description": "Visibilidade define quem, além do autor, pode ver a publicação",
+                    "type": "string"
+                }
+            }
+        },
+        "models.PublicacaoEncontrada": {
+            "type": "object",
+            "properties": {
+                "CriadaEm": {
+                    "type": "string"
+                },
+                "anexos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Anexo"
                     }
-                ],
-                "description": "Busca publicações de um usuário específico",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "publicacoes"
-                ],
-                "summary": "Buscar Publicações do Usuário",
-                "parameters": [
-                    {
+                },
+                "autorAvatar": {
+                    "$ref": "#/definitions/models.ImagemPerfil"
+                },
+                "autorId": {
+                    "type": "integer"
+                },
+                "autorNick": {
+                    "type": "string"
+                },
+                "citacao": {
+                    "description": "Citacao é a publicação embutida quando esta é uma citação",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Publicacao"
+                        }
+                    ]
+                },
+                "citacaoDe": {
+                    "type": "integer"
+                },
+                "citacoes": {
+                    "type": "integer"
+                },
+                "comentarios": {
+                    "type": "integer"
+                },
+                "conteudo": {
+                    "type": "string"
+                },
+                "curtidas": {
+                    "type": "integer"
+                },
+                "curtidoPorMim": {
+                    "description": "CurtidoPorMim indica se o usuário autenticado curtiu a publicação",
+                    "type": "boolean"
+                },
+                "edicoes": {
+                    "type": "integer"
+                },
+                "editadaAposMinhaReacao": {
+                    "description": "EditadaAposMinhaReacao indica se a publicação foi editada depois que o usuário autenticado reagiu a ela",
+                    "type": "boolean"
+                },
+                "editadaEm": {
+                    "type": "string"
+                },
+                "emRespostaA": {
+                    "type": "integer"
+                },
+                "fixada": {
+                    "description": "Fixada indica a publicação fixada no topo do perfil do autor",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mencoes": {
+                    "description": "Mencoes são os @nick do conteúdo que correspondem a usuários",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Mencao"
+                    }
+                },
+                "minha": {
+                    "description": "Minha indica se o usuário autenticado é o autor",
+                    "type": "boolean"
+                },
+                "minhaReacao": {
+                    "description": "MinhaReacao é o tipo da reação do usuário autenticado, se houver",
+                    "type": "string"
+                },
+                "pontuacao": {
+                    "type": "number"
+                },
+                "publicarEm": {
+                    "type": "string"
+                },
+                "reacoes": {
+                    "description": "Reacoes traz a quantidade de reações por tipo",
+                    "type": "object",
+                    "additionalProperties": {
                         "type": "integer",
-                        "description": "ID do usuário",
-                        "name": "usuarioId",
-                        "in": "path",
-                        "required": true
+                        "format": "int64"
                     }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Publicacao"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                },
+                "republicacoes": {
+                    "type": "integer"
+                },
+                "republicadaPor": {
+                    "description": "RepublicadaPor é preenchido quando o item do feed é uma republicação",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Republicacao"
                         }
+                    ]
+                },
+                "republicadoPorMim": {
+                    "description": "RepublicadoPorMim indica se o usuário autenticado republicou a publicação",
+                    "type": "boolean"
+                },
+                "respostas": {
+                    "type": "integer"
+                },
+                "salvo": {
+                    "description": "Salvo indica se o usuário autenticado salvou a publicação",
+                    "type": "boolean"
+                },
+                "sigoAutor": {
+                    "description": "SigoAutor indica se o usuário autenticado segue o autor",
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "Tags são as hashtags do título e do conteúdo, extraídas em Preparar",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "models.AlterarSenha": {
-            "type": "object",
-            "properties": {
-                "atual": {
+                },
+                "titulo": {
                     "type": "string"
                 },
-                "nova": {
+                "trecho": {
+                    "description": "Trecho é a parte do texto em que os termos aparecem, com cada ocorrência entre \u003cmark\u003e e \u003c/mark\u003e.\nO restante do texto vem escapado para HTML",
+                    "type": "string"
+                },
+                "visibilidade": {
+                    "description": "Visibilidade define quem, além do autor, pode ver a publicação",
                     "type": "string"
                 }
             }
         },
-        "models.CreateUsuarioRequest": {
+        "models.PublicacaoRequest": {
             "type": "object",
             "properties": {
-                "email": {
+                "citacaoDe": {
+                    "description": "CitacaoDe é o id da publicação citada. Só é lido na criação",
+                    "type": "integer"
+                },
+                "conteudo": {
                     "type": "string"
                 },
-                "nick": {
+                "emRespostaA": {
+                    "description": "EmRespostaA é o id da publicação respondida. Só é lido na criação",
+                    "type": "integer"
+                },
+                "publicarEm": {
+                    "description": "PublicarEm é obrigatório quando o status é agendada",
                     "type": "string"
                 },
-                "nome": {
+                "status": {
+                    "description": "Status é rascunho, agendada ou publicada (padrão). Só é lido na criação e em /rascunhos",
                     "type": "string"
                 },
-                "senha": {
+                "titulo": {
+                    "type": "string"
+                },
+                "visibilidade": {
+                    "description": "Visibilidade é publica (padrão), seguidores ou mencionados. Só é lida na criação e em /rascunhos",
                     "type": "string"
                 }
             }
         },
-        "models.LoginRequest": {
+        "models.Reacao": {
             "type": "object",
-            "required": [
-                "email",
-                "senha"
-            ],
             "properties": {
-                "email": {
+                "CriadaEm": {
                     "type": "string"
                 },
-                "senha": {
+                "tipo": {
+                    "type": "string"
+                },
+                "usuarioAvatar": {
+                    "$ref": "#/definitions/models.ImagemPerfil"
+                },
+                "usuarioId": {
+                    "type": "integer"
+                },
+                "usuarioNick": {
                     "type": "string"
                 }
             }
         },
-        "models.LoginResponse": {
+        "models.ReacaoRequest": {
             "type": "object",
             "properties": {
-                "token": {
+                "tipo": {
                     "type": "string"
+                }
+            }
+        },
+        "models.Relacao": {
+            "type": "object",
+            "properties": {
+                "bloqueado": {
+                    "type": "boolean"
                 },
-                "userId": {
+                "bloqueiaVoce": {
+                    "type": "boolean"
+                },
+                "segueVoce": {
+                    "type": "boolean"
+                },
+                "seguidoresEmComum": {
+                    "type": "integer"
+                },
+                "seguindo": {
+                    "type": "boolean"
+                },
+                "silenciado": {
+                    "type": "boolean"
+                },
+                "solicitado": {
+                    "type": "boolean"
+                },
+                "solicitouVoce": {
+                    "type": "boolean"
+                },
+                "usuarioId": {
                     "type": "integer"
                 }
             }
         },
-        "models.Publicacao": {
+        "models.Republicacao": {
             "type": "object",
             "properties": {
                 "CriadaEm": {
                     "type": "string"
                 },
-                "autorId": {
+                "usuarioAvatar": {
+                    "$ref": "#/definitions/models.ImagemPerfil"
+                },
+                "usuarioId": {
                     "type": "integer"
                 },
-                "autorNick": {
+                "usuarioNick": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RespostaConversa": {
+            "type": "object",
+            "properties": {
+                "publicacao": {
+                    "$ref": "#/definitions/models.Publicacao"
+                },
+                "respostas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RespostaConversa"
+                    }
+                }
+            }
+        },
+        "models.Revisao": {
+            "type": "object",
+            "properties": {
+                "CriadaEm": {
                     "type": "string"
                 },
                 "conteudo": {
                     "type": "string"
                 },
-                "curtidas": {
-                    "type": "integer"
+                "diffConteudo": {
+                    "type": "string"
                 },
-                "id": {
+                "diffTitulo": {
+                    "description": "DiffTitulo e DiffConteudo comparam com a revisão anterior, marcando\no que saiu com [-...-] e o que entrou com {+...+}",
+                    "type": "string"
+                },
+                "numero": {
                     "type": "integer"
                 },
                 "titulo": {
@@ -1209,13 +7175,41 @@ const docTemplate = `{
                 }
             }
         },
-        "models.PublicacaoRequest": {
+        "models.SalvarRequest": {
             "type": "object",
             "properties": {
-                "conteudo": {
-                    "type": "string"
+                "colecaoId": {
+                    "description": "ColecaoId é a coleção onde a publicação fica. Sem ela, a publicação fica fora de coleções",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Sugestao": {
+            "type": "object",
+            "properties": {
+                "segueVoce": {
+                    "description": "SegueVoce indica que a conta sugerida já segue o usuário",
+                    "type": "boolean"
                 },
-                "titulo": {
+                "seguidoresEmComum": {
+                    "description": "SeguidoresEmComum é quantas das contas que o usuário segue seguem a sugerida",
+                    "type": "integer"
+                },
+                "usuario": {
+                    "$ref": "#/definitions/models.Usuario"
+                }
+            }
+        },
+        "models.TagEmAlta": {
+            "type": "object",
+            "properties": {
+                "pontuacao": {
+                    "type": "number"
+                },
+                "publicacoes": {
+                    "type": "integer"
+                },
+                "tag": {
                     "type": "string"
                 }
             }
@@ -1240,20 +7234,54 @@ const docTemplate = `{
                 "CriadoEm": {
                     "type": "string"
                 },
+                "avatar": {
+                    "$ref": "#/definitions/models.ImagemPerfil"
+                },
+                "banner": {
+                    "$ref": "#/definitions/models.ImagemPerfil"
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "dataNascimento": {
+                    "description": "DataNascimento está no formato AAAA-MM-DD e só aparece para outros usuários quando DataNascimentoPublica",
+                    "type": "string"
+                },
+                "dataNascimentoPublica": {
+                    "type": "boolean"
+                },
                 "email": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "localizacao": {
+                    "type": "string"
+                },
+                "mensagensApenasSeguidos": {
+                    "description": "MensagensApenasSeguidos restringe as mensagens diretas recebidas a quem o usuário segue",
+                    "type": "boolean"
+                },
                 "nick": {
                     "type": "string"
                 },
                 "nome": {
                     "type": "string"
                 },
+                "privado": {
+                    "description": "Privado faz os follows virarem solicitações e mostra publicações e seguidores só a seguidores aceitos",
+                    "type": "boolean"
+                },
+                "publicacaoFixadaId": {
+                    "description": "PublicacaoFixadaId é a publicação exibida no topo do perfil",
+                    "type": "integer"
+                },
                 "senha": {
                     "type": "string"
+                },
+                "site": {
+                    "type": "string"
                 }
             }
         }
@@ -10,8 +10,13 @@ package main
 // @name Authorization
 
 import (
+	"api/src/agendador"
+	"api/src/busca"
 	"api/src/config"
+	"api/src/eventos"
 	"api/src/router"
+	"api/src/sugestoes"
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -21,6 +26,13 @@ func main() {
 	config.Carregar()
 	r := router.Gerar()
 
+	go agendador.Iniciar(context.Background(), config.IntervaloAgendador)
+	go busca.Iniciar(context.Background())
+	go sugestoes.Iniciar(context.Background(), config.SugestoesIntervalo)
+	eventos.Iniciar(context.Background(),
+		eventos.FonteBanco{Intervalo: config.EventosIntervalo, Retencao: config.EventosRetencao},
+		config.EventosFila)
+
 	fmt.Printf("Rodando API na porta %d", config.Porta)
 	porta := fmt.Sprintf("localhost:%d", config.Porta)
 	if config.DockerRun {
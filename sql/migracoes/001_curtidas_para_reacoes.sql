@@ -0,0 +1,18 @@
+-- Move as curtidas existentes para a tabela de reações como reações do tipo "curtir"
+USE devbook;
+
+CREATE TABLE IF NOT EXISTS reacoes(
+    usuario_id int not null,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    publicacao_id int not null,
+    FOREIGN KEY (publicacao_id) REFERENCES publicacoes(id) ON DELETE CASCADE,
+    tipo varchar(20) not null,
+    criadaEm timestamp default current_timestamp(),
+    PRIMARY KEY(usuario_id, publicacao_id),
+    INDEX idx_reacoes_publicacao (publicacao_id, tipo)
+);
+
+INSERT IGNORE INTO reacoes (usuario_id, publicacao_id, tipo)
+SELECT usuario_id, publicacao_id, 'curtir' FROM curtidas;
+
+DROP TABLE curtidas;
@@ -9,7 +9,16 @@ CREATE TABLE usuarios(
     nick varchar(50) not null unique,
     email varchar(50) not null unique,
     senha varchar(100) not null,
-    criadoEm timestamp default current_timestamp()
+    criadoEm timestamp default current_timestamp(),
+    avatar varchar(200) null default null,
+    banner varchar(200) null default null,
+    bio varchar(160) not null default '',
+    localizacao varchar(50) not null default '',
+    site varchar(100) not null default '',
+    dataNascimento date null default null,
+    dataNascimentoPublica boolean not null default false,
+    mensagensApenasSeguidos boolean not null default false,
+    privado boolean not null default false
 );
 
 DROP TABLE IF EXISTS seguidores;
@@ -22,6 +31,17 @@ CREATE TABLE seguidores(
     PRIMARY KEY(usuario_id, seguidor_id)
 );
 
+DROP TABLE IF EXISTS solicitacoes_seguir;
+
+CREATE TABLE solicitacoes_seguir(
+    usuario_id int not null,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    solicitante_id int not null,
+    FOREIGN KEY (solicitante_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    criadaEm timestamp default current_timestamp(),
+    PRIMARY KEY(usuario_id, solicitante_id)
+);
+
 DROP TABLE IF EXISTS publicacoes;
 
 CREATE TABLE publicacoes(
@@ -30,17 +50,293 @@ CREATE TABLE publicacoes(
     conteudo VARCHAR(300) NOT NULL,
     autor_id int not null,
     FOREIGN KEY (autor_id) REFERENCES usuarios(id) ON DELETE CASCADE,
-    criadaEm timestamp default current_timestamp()
+    em_resposta_a int null,
+    FOREIGN KEY (em_resposta_a) REFERENCES publicacoes(id) ON DELETE SET NULL,
+    citacao_de int null,
+    FOREIGN KEY (citacao_de) REFERENCES publicacoes(id) ON DELETE SET NULL,
+    criadaEm timestamp default current_timestamp(),
+    editadaEm timestamp null default null,
+    edicoes int not null default 0,
+    status ENUM('rascunho', 'agendada', 'publicada') not null default 'publicada',
+    publicarEm timestamp null default null,
+    visibilidade ENUM('publica', 'seguidores', 'mencionados') not null default 'publica',
+    INDEX idx_publicacoes_agendadas (status, publicarEm),
+    FULLTEXT INDEX ft_publicacoes_texto (titulo, conteudo)
+);
+
+ALTER TABLE usuarios ADD COLUMN publicacao_fixada int null default null,
+    ADD FOREIGN KEY (publicacao_fixada) REFERENCES publicacoes(id) ON DELETE SET NULL;
+
+DROP TABLE IF EXISTS reacoes;
+
+CREATE TABLE reacoes(
+    usuario_id int not null,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    publicacao_id int not null,
+    FOREIGN KEY (publicacao_id) REFERENCES publicacoes(id) ON DELETE CASCADE,
+    tipo varchar(20) not null,
+    criadaEm timestamp default current_timestamp(),
+    PRIMARY KEY(usuario_id, publicacao_id),
+    INDEX idx_reacoes_publicacao (publicacao_id, tipo)
+);
+
+DROP TABLE IF EXISTS comentarios;
+
+CREATE TABLE comentarios(
+    id int auto_increment primary key,
+    publicacao_id int not null,
+    FOREIGN KEY (publicacao_id) REFERENCES publicacoes(id) ON DELETE CASCADE,
+    autor_id int not null,
+    FOREIGN KEY (autor_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    conteudo VARCHAR(300) NOT NULL,
+    criadoEm timestamp default current_timestamp(),
+    atualizadoEm timestamp null default null,
+    INDEX idx_comentarios_publicacao (publicacao_id, id)
 );
 
-DROP TABLE IF EXISTS curtidas;
+DROP TABLE IF EXISTS republicacoes;
 
-CREATE TABLE curtidas(
+CREATE TABLE republicacoes(
     usuario_id int not null,
     FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
     publicacao_id int not null,
     FOREIGN KEY (publicacao_id) REFERENCES publicacoes(id) ON DELETE CASCADE,
+    criadaEm timestamp default current_timestamp(),
     PRIMARY KEY(usuario_id, publicacao_id)
 );
 
+DROP TABLE IF EXISTS revisoes;
+
+CREATE TABLE revisoes(
+    id int auto_increment primary key,
+    publicacao_id int not null,
+    FOREIGN KEY (publicacao_id) REFERENCES publicacoes(id) ON DELETE CASCADE,
+    numero int not null,
+    titulo VARCHAR(50) NOT NULL,
+    conteudo VARCHAR(300) NOT NULL,
+    criadaEm timestamp not null,
+    UNIQUE (publicacao_id, numero)
+);
+
+DROP TABLE IF EXISTS anexos;
+
+CREATE TABLE anexos(
+    id int auto_increment primary key,
+    publicacao_id int not null,
+    FOREIGN KEY (publicacao_id) REFERENCES publicacoes(id) ON DELETE CASCADE,
+    chave varchar(200) not null,
+    chave_miniatura varchar(200) not null,
+    tipo varchar(50) not null,
+    largura int not null,
+    altura int not null,
+    tamanho int not null,
+    criadoEm timestamp default current_timestamp()
+);
+
+DROP TABLE IF EXISTS tags;
+
+CREATE TABLE tags(
+    id int auto_increment primary key,
+    nome varchar(50) not null unique
+);
+
+DROP TABLE IF EXISTS publicacoes_tags;
+
+CREATE TABLE publicacoes_tags(
+    publicacao_id int not null,
+    FOREIGN KEY (publicacao_id) REFERENCES publicacoes(id) ON DELETE CASCADE,
+    tag_id int not null,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY(publicacao_id, tag_id),
+    INDEX idx_publicacoes_tags_tag (tag_id, publicacao_id)
+);
+
+DROP TABLE IF EXISTS mencoes;
+
+CREATE TABLE mencoes(
+    publicacao_id int not null,
+    FOREIGN KEY (publicacao_id) REFERENCES publicacoes(id) ON DELETE CASCADE,
+    usuario_id int not null,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    inicio int not null,
+    fim int not null,
+    PRIMARY KEY(publicacao_id, inicio),
+    INDEX idx_mencoes_usuario (usuario_id)
+);
+
+DROP TABLE IF EXISTS notificacoes;
+
+CREATE TABLE notificacoes(
+    id int auto_increment primary key,
+    usuario_id int not null,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    tipo varchar(20) not null,
+    publicacao_id int null,
+    FOREIGN KEY (publicacao_id) REFERENCES publicacoes(id) ON DELETE CASCADE,
+    lida boolean not null default false,
+    criadaEm timestamp default current_timestamp(),
+    atualizadaEm timestamp default current_timestamp(),
+    INDEX idx_notificacoes_usuario (usuario_id, lida, atualizadaEm),
+    INDEX idx_notificacoes_grupo (usuario_id, tipo, publicacao_id, lida)
+);
+
+DROP TABLE IF EXISTS notificacoes_atores;
+
+CREATE TABLE notificacoes_atores(
+    notificacao_id int not null,
+    FOREIGN KEY (notificacao_id) REFERENCES notificacoes(id) ON DELETE CASCADE,
+    ator_id int not null,
+    FOREIGN KEY (ator_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    criadoEm timestamp default current_timestamp(),
+    PRIMARY KEY(notificacao_id, ator_id)
+);
+
+DROP TABLE IF EXISTS preferencias_notificacao;
+
+CREATE TABLE preferencias_notificacao(
+    usuario_id int not null,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    tipo varchar(20) not null,
+    ativa boolean not null,
+    PRIMARY KEY(usuario_id, tipo)
+);
+
+DROP TABLE IF EXISTS eventos;
+
+CREATE TABLE eventos(
+    id bigint auto_increment primary key,
+    tipo varchar(20) not null,
+    usuario_id int null,
+    autor_id int null,
+    dados json not null,
+    criadoEm timestamp default current_timestamp(),
+    INDEX idx_eventos_criado (criadoEm)
+);
+
+DROP TABLE IF EXISTS conversas;
+
+CREATE TABLE conversas(
+    id int auto_increment primary key,
+    grupo boolean not null default false,
+    nome varchar(50) not null default '',
+    -- chave identifica a conversa entre duas pessoas ("menorId:maiorId"), para não criar outra igual
+    chave varchar(41) null default null unique,
+    criador_id int null,
+    FOREIGN KEY (criador_id) REFERENCES usuarios(id) ON DELETE SET NULL,
+    criadaEm timestamp default current_timestamp(),
+    atualizadaEm timestamp default current_timestamp()
+);
+
+DROP TABLE IF EXISTS mensagens;
+
+CREATE TABLE mensagens(
+    id bigint auto_increment primary key,
+    conversa_id int not null,
+    FOREIGN KEY (conversa_id) REFERENCES conversas(id) ON DELETE CASCADE,
+    autor_id int not null,
+    FOREIGN KEY (autor_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    texto varchar(1000) not null,
+    criadaEm timestamp default current_timestamp(),
+    INDEX idx_mensagens_conversa (conversa_id, id)
+);
+
+DROP TABLE IF EXISTS conversas_participantes;
+
+CREATE TABLE conversas_participantes(
+    conversa_id int not null,
+    FOREIGN KEY (conversa_id) REFERENCES conversas(id) ON DELETE CASCADE,
+    usuario_id int not null,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    -- ultima_lida_id é a última mensagem lida; oculta_ate esconde do participante as mensagens até ela
+    ultima_lida_id bigint null default null,
+    oculta_ate bigint null default null,
+    entrouEm timestamp default current_timestamp(),
+    PRIMARY KEY(conversa_id, usuario_id),
+    INDEX idx_participantes_usuario (usuario_id)
+);
+
+DROP TABLE IF EXISTS mensagens_ocultas;
+
+CREATE TABLE mensagens_ocultas(
+    mensagem_id bigint not null,
+    FOREIGN KEY (mensagem_id) REFERENCES mensagens(id) ON DELETE CASCADE,
+    usuario_id int not null,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    PRIMARY KEY(mensagem_id, usuario_id)
+);
+
+DROP TABLE IF EXISTS bloqueios;
+
+CREATE TABLE bloqueios(
+    usuario_id int not null,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    bloqueado_id int not null,
+    FOREIGN KEY (bloqueado_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    criadoEm timestamp default current_timestamp(),
+    PRIMARY KEY(usuario_id, bloqueado_id),
+    INDEX idx_bloqueios_bloqueado (bloqueado_id)
+);
+
+DROP TABLE IF EXISTS silenciados;
+
+CREATE TABLE silenciados(
+    usuario_id int not null,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    silenciado_id int not null,
+    FOREIGN KEY (silenciado_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    criadoEm timestamp default current_timestamp(),
+    PRIMARY KEY(usuario_id, silenciado_id)
+);
+
+DROP TABLE IF EXISTS colecoes;
+
+CREATE TABLE colecoes(
+    id int auto_increment primary key,
+    usuario_id int not null,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    nome varchar(50) not null,
+    criadaEm timestamp default current_timestamp(),
+    UNIQUE KEY uk_colecoes_nome (usuario_id, nome)
+);
+
+DROP TABLE IF EXISTS salvos;
+
+CREATE TABLE salvos(
+    usuario_id int not null,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    publicacao_id int not null,
+    FOREIGN KEY (publicacao_id) REFERENCES publicacoes(id) ON DELETE CASCADE,
+    colecao_id int null,
+    FOREIGN KEY (colecao_id) REFERENCES colecoes(id) ON DELETE SET NULL,
+    salvoEm timestamp default current_timestamp(),
+    PRIMARY KEY(usuario_id, publicacao_id),
+    INDEX idx_salvos_usuario (usuario_id, salvoEm)
+);
+
+DROP TABLE IF EXISTS sugestoes;
+
+CREATE TABLE sugestoes(
+    usuario_id int not null,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    sugerido_id int not null,
+    FOREIGN KEY (sugerido_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    seguidoresEmComum int not null default 0,
+    segueVoce boolean not null default false,
+    publicacoesRecentes int not null default 0,
+    pontuacao double not null,
+    PRIMARY KEY(usuario_id, sugerido_id),
+    INDEX idx_sugestoes_usuario (usuario_id, pontuacao)
+);
+
+DROP TABLE IF EXISTS sugestoes_dispensadas;
+
+CREATE TABLE sugestoes_dispensadas(
+    usuario_id int not null,
+    FOREIGN KEY (usuario_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    sugerido_id int not null,
+    FOREIGN KEY (sugerido_id) REFERENCES usuarios(id) ON DELETE CASCADE,
+    dispensadaEm timestamp default current_timestamp(),
+    PRIMARY KEY(usuario_id, sugerido_id)
+);
+
 GRANT ALL PRIVILEGES ON devbook.* TO 'localUserDocker'@'%';
\ No newline at end of file
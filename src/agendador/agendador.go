@@ -0,0 +1,46 @@
+// Package agendador publica as publicações agendadas quando chega a hora delas
+package agendador
+
+import (
+	"api/src/database"
+	"api/src/repositories"
+	"context"
+	"log"
+	"time"
+)
+
+// Iniciar roda até o contexto ser cancelado, publicando as agendadas vencidas a cada intervalo.
+// A primeira rodada é imediata, então o que venceu com a API fora do ar sai logo após a subida
+func Iniciar(ctx context.Context, intervalo time.Duration) {
+	ticker := time.NewTicker(intervalo)
+	defer ticker.Stop()
+
+	for {
+		publicarAgendadas()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func publicarAgendadas() {
+	db, erro := database.Conectar()
+	if erro != nil {
+		log.Printf("agendador: erro ao conectar no banco: %v", erro)
+		return
+	}
+	defer db.Close()
+
+	publicadas, erro := repositories.NewPublicacoesRepo(db).PublicarAgendadas(time.Now())
+	if erro != nil {
+		log.Printf("agendador: erro ao publicar agendadas: %v", erro)
+		return
+	}
+
+	if publicadas > 0 {
+		log.Printf("agendador: %d publicações agendadas publicadas", publicadas)
+	}
+}
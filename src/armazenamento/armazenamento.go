@@ -0,0 +1,35 @@
+// Package armazenamento guarda os arquivos enviados pelos usuários,
+// em disco ou em um serviço compatível com S3
+package armazenamento
+
+import (
+	"api/src/config"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNaoEncontrado é retornado por Abrir quando não há arquivo com a chave
+var ErrNaoEncontrado = errors.New("Arquivo não encontrado")
+
+// Armazenamento é onde os arquivos de mídia ficam guardados.
+// A chave é um caminho relativo, como "anexos/10/abc.jpg"
+type Armazenamento interface {
+	Salvar(ctx context.Context, chave string, conteudo []byte, tipo string) error
+	Abrir(ctx context.Context, chave string) (io.ReadCloser, error)
+	Remover(ctx context.Context, chave string) error
+}
+
+// Novo cria o armazenamento escolhido pela variável MIDIA_ARMAZENAMENTO
+func Novo() (Armazenamento, error) {
+	switch config.MidiaArmazenamento {
+	case "local":
+		return NewLocal(config.MidiaDiretorio), nil
+	case "s3":
+		return NewS3(config.S3Endpoint, config.S3Bucket, config.S3Regiao,
+			config.S3AccessKey, config.S3SecretKey), nil
+	default:
+		return nil, fmt.Errorf("Armazenamento de mídia desconhecido: %s", config.MidiaArmazenamento)
+	}
+}
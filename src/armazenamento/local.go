@@ -0,0 +1,81 @@
+package armazenamento
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local guarda os arquivos em um diretório do sistema de arquivos
+type Local struct {
+	diretorio string
+}
+
+func NewLocal(diretorio string) *Local {
+	return &Local{diretorio}
+}
+
+func (local Local) Salvar(_ context.Context, chave string, conteudo []byte, _ string) error {
+	caminho, erro := local.caminho(chave)
+	if erro != nil {
+		return erro
+	}
+
+	if erro := os.MkdirAll(filepath.Dir(caminho), 0o755); erro != nil {
+		return erro
+	}
+
+	// Grava em um temporário e renomeia para que ninguém leia um arquivo pela metade
+	temporario, erro := os.CreateTemp(filepath.Dir(caminho), ".envio-*")
+	if erro != nil {
+		return erro
+	}
+	defer os.Remove(temporario.Name())
+
+	if _, erro := temporario.Write(conteudo); erro != nil {
+		temporario.Close()
+		return erro
+	}
+	if erro := temporario.Close(); erro != nil {
+		return erro
+	}
+
+	return os.Rename(temporario.Name(), caminho)
+}
+
+func (local Local) Abrir(_ context.Context, chave string) (io.ReadCloser, error) {
+	caminho, erro := local.caminho(chave)
+	if erro != nil {
+		return nil, erro
+	}
+
+	arquivo, erro := os.Open(caminho)
+	if errors.Is(erro, os.ErrNotExist) {
+		return nil, ErrNaoEncontrado
+	}
+	return arquivo, erro
+}
+
+func (local Local) Remover(_ context.Context, chave string) error {
+	caminho, erro := local.caminho(chave)
+	if erro != nil {
+		return erro
+	}
+
+	if erro := os.Remove(caminho); erro != nil && !errors.Is(erro, os.ErrNotExist) {
+		return erro
+	}
+	return nil
+}
+
+// caminho resolve a chave dentro do diretório, recusando chaves que escapem dele
+func (local Local) caminho(chave string) (string, error) {
+	limpa := filepath.Clean("/" + chave)
+	if limpa == "/" || strings.Contains(chave, "..") {
+		return "", errors.New("Chave de arquivo inválida")
+	}
+	return filepath.Join(local.diretorio, filepath.FromSlash(limpa)), nil
+}
@@ -0,0 +1,179 @@
+package armazenamento
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// S3 guarda os arquivos em um bucket de um serviço compatível com S3 (AWS, MinIO, R2...).
+// Usa endereçamento por caminho ({endpoint}/{bucket}/{chave}) e assinatura AWS v4,
+// então pode ser testado contra um MinIO local
+type S3 struct {
+	endpoint  string
+	bucket    string
+	regiao    string
+	accessKey string
+	secretKey string
+	cliente   *http.Client
+}
+
+func NewS3(endpoint, bucket, regiao, accessKey, secretKey string) *S3 {
+	return &S3{
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+		bucket:    bucket,
+		regiao:    regiao,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		cliente:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (s3 S3) Salvar(ctx context.Context, chave string, conteudo []byte, tipo string) error {
+	requisicao, erro := s3.novaRequisicao(ctx, http.MethodPut, chave, conteudo)
+	if erro != nil {
+		return erro
+	}
+	requisicao.Header.Set("Content-Type", tipo)
+
+	resposta, erro := s3.cliente.Do(requisicao)
+	if erro != nil {
+		return erro
+	}
+	defer resposta.Body.Close()
+
+	if resposta.StatusCode != http.StatusOK {
+		return erroS3(resposta)
+	}
+	return nil
+}
+
+func (s3 S3) Abrir(ctx context.Context, chave string) (io.ReadCloser, error) {
+	requisicao, erro := s3.novaRequisicao(ctx, http.MethodGet, chave, nil)
+	if erro != nil {
+		return nil, erro
+	}
+
+	resposta, erro := s3.cliente.Do(requisicao)
+	if erro != nil {
+		return nil, erro
+	}
+
+	switch resposta.StatusCode {
+	case http.StatusOK:
+		return resposta.Body, nil
+	case http.StatusNotFound:
+		resposta.Body.Close()
+		return nil, ErrNaoEncontrado
+	default:
+		defer resposta.Body.Close()
+		return nil, erroS3(resposta)
+	}
+}
+
+func (s3 S3) Remover(ctx context.Context, chave string) error {
+	requisicao, erro := s3.novaRequisicao(ctx, http.MethodDelete, chave, nil)
+	if erro != nil {
+		return erro
+	}
+
+	resposta, erro := s3.cliente.Do(requisicao)
+	if erro != nil {
+		return erro
+	}
+	defer resposta.Body.Close()
+
+	if resposta.StatusCode != http.StatusNoContent && resposta.StatusCode != http.StatusOK {
+		return erroS3(resposta)
+	}
+	return nil
+}
+
+// novaRequisicao monta a requisição para o objeto já assinada com AWS Signature Version 4
+func (s3 S3) novaRequisicao(ctx context.Context, metodo string, chave string, conteudo []byte) (*http.Request, error) {
+	caminho := "/" + s3.bucket + "/" + codificarCaminho(chave)
+	requisicao, erro := http.NewRequestWithContext(ctx, metodo, s3.endpoint+caminho, bytes.NewReader(conteudo))
+	if erro != nil {
+		return nil, erro
+	}
+
+	agora := time.Now().UTC()
+	data := agora.Format("20060102")
+	dataHora := agora.Format("20060102T150405Z")
+	hashConteudo := sha256Hex(conteudo)
+
+	requisicao.Header.Set("X-Amz-Date", dataHora)
+	requisicao.Header.Set("X-Amz-Content-Sha256", hashConteudo)
+
+	cabecalhosAssinados := "host;x-amz-content-sha256;x-amz-date"
+	requisicaoCanonica := strings.Join([]string{
+		metodo,
+		caminho,
+		"",
+		"host:" + requisicao.URL.Host,
+		"x-amz-content-sha256:" + hashConteudo,
+		"x-amz-date:" + dataHora,
+		"",
+		cabecalhosAssinados,
+		hashConteudo,
+	}, "\n")
+
+	escopo := data + "/" + s3.regiao + "/s3/aws4_request"
+	textoAssinado := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		dataHora,
+		escopo,
+		sha256Hex([]byte(requisicaoCanonica)),
+	}, "\n")
+
+	chaveAssinatura := hmacSHA256([]byte("AWS4"+s3.secretKey), data)
+	chaveAssinatura = hmacSHA256(chaveAssinatura, s3.regiao)
+	chaveAssinatura = hmacSHA256(chaveAssinatura, "s3")
+	chaveAssinatura = hmacSHA256(chaveAssinatura, "aws4_request")
+	assinatura := hex.EncodeToString(hmacSHA256(chaveAssinatura, textoAssinado))
+
+	requisicao.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3.accessKey, escopo, cabecalhosAssinados, assinatura))
+
+	return requisicao, nil
+}
+
+// codificarCaminho aplica o URI encode do S3 na chave: só letras, números e -._~
+// ficam como estão, e as barras separam os segmentos
+func codificarCaminho(chave string) string {
+	var codificado strings.Builder
+	for _, b := range []byte(chave) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '.', b == '_', b == '~', b == '/':
+			codificado.WriteByte(b)
+		default:
+			fmt.Fprintf(&codificado, "%%%02X", b)
+		}
+	}
+	return codificado.String()
+}
+
+func sha256Hex(dados []byte) string {
+	hash := sha256.Sum256(dados)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(chave []byte, dados string) []byte {
+	mac := hmac.New(sha256.New, chave)
+	mac.Write([]byte(dados))
+	return mac.Sum(nil)
+}
+
+func erroS3(resposta *http.Response) error {
+	corpo, _ := io.ReadAll(io.LimitReader(resposta.Body, 1024))
+	return fmt.Errorf("S3 respondeu %d: %s", resposta.StatusCode, strings.TrimSpace(string(corpo)))
+}
@@ -0,0 +1,198 @@
+package armazenamento
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	bucketTeste    = "devbook"
+	regiaoTeste    = "us-east-1"
+	accessKeyTeste = "AKIATESTE"
+	secretKeyTeste = "segredo/teste+123"
+)
+
+var autorizacaoV4 = regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=([^/]+)/(\d{8})/([^/]+)/s3/aws4_request, SignedHeaders=([^,]+), Signature=([0-9a-f]{64})$`)
+
+// s3Falso é um bucket em memória que só aceita requisições com assinatura v4 válida
+type s3Falso struct {
+	mutex   sync.Mutex
+	objetos map[string][]byte
+	tipos   map[string]string
+}
+
+func novoS3Falso(t *testing.T) (*S3, *s3Falso) {
+	t.Helper()
+	falso := &s3Falso{objetos: map[string][]byte{}, tipos: map[string]string{}}
+	servidor := httptest.NewServer(falso)
+	t.Cleanup(servidor.Close)
+
+	return NewS3(servidor.URL+"/", bucketTeste, regiaoTeste, accessKeyTeste, secretKeyTeste), falso
+}
+
+func (falso *s3Falso) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	corpo, _ := io.ReadAll(r.Body)
+	if erro := verificarAssinaturaV4(r, corpo); erro != nil {
+		http.Error(w, erro.Error(), http.StatusForbidden)
+		return
+	}
+
+	prefixo := "/" + bucketTeste + "/"
+	if !strings.HasPrefix(r.URL.Path, prefixo) {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+	chave := strings.TrimPrefix(r.URL.Path, prefixo)
+
+	falso.mutex.Lock()
+	defer falso.mutex.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		falso.objetos[chave] = corpo
+		falso.tipos[chave] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		conteudo, ok := falso.objetos[chave]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Write(conteudo)
+	case http.MethodDelete:
+		delete(falso.objetos, chave)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// verificarAssinaturaV4 refaz a assinatura do lado do servidor, a partir do que chegou pela rede
+func verificarAssinaturaV4(r *http.Request, corpo []byte) error {
+	partes := autorizacaoV4.FindStringSubmatch(r.Header.Get("Authorization"))
+	if partes == nil {
+		return errors.New("Authorization malformado")
+	}
+	accessKey, data, regiao, assinados, assinatura := partes[1], partes[2], partes[3], partes[4], partes[5]
+	if accessKey != accessKeyTeste || regiao != regiaoTeste {
+		return errors.New("credencial desconhecida")
+	}
+
+	hash := sha256.Sum256(corpo)
+	if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(hash[:]) {
+		return errors.New("hash do conteúdo não confere")
+	}
+
+	dataHora := r.Header.Get("X-Amz-Date")
+	if !strings.HasPrefix(dataHora, data) {
+		return errors.New("data do escopo não confere")
+	}
+
+	canonicos := []string{}
+	for _, nome := range strings.Split(assinados, ";") {
+		valor := r.Header.Get(nome)
+		if nome == "host" {
+			valor = r.Host
+		}
+		canonicos = append(canonicos, nome+":"+strings.TrimSpace(valor))
+	}
+	requisicaoCanonica := strings.Join([]string{
+		r.Method, r.URL.EscapedPath(), r.URL.RawQuery,
+		strings.Join(canonicos, "\n") + "\n",
+		assinados, r.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+
+	hashCanonica := sha256.Sum256([]byte(requisicaoCanonica))
+	texto := "AWS4-HMAC-SHA256\n" + dataHora + "\n" + data + "/" + regiao + "/s3/aws4_request\n" +
+		hex.EncodeToString(hashCanonica[:])
+
+	chave := []byte("AWS4" + secretKeyTeste)
+	for _, parte := range []string{data, regiao, "s3", "aws4_request", texto} {
+		mac := hmac.New(sha256.New, chave)
+		mac.Write([]byte(parte))
+		chave = mac.Sum(nil)
+	}
+
+	if !hmac.Equal([]byte(hex.EncodeToString(chave)), []byte(assinatura)) {
+		return errors.New("SignatureDoesNotMatch")
+	}
+	return nil
+}
+
+func TestS3SalvarAbrirRemover(t *testing.T) {
+	s3, falso := novoS3Falso(t)
+	ctx := context.Background()
+
+	for _, chave := range []string{"anexos/10/abc.jpg", "perfis/1/avatar com espaço+ção.png"} {
+		conteudo := []byte("conteúdo de " + chave)
+		if erro := s3.Salvar(ctx, chave, conteudo, "image/png"); erro != nil {
+			t.Fatalf("Salvar(%q): %v", chave, erro)
+		}
+		if falso.tipos[chave] != "image/png" {
+			t.Errorf("Content-Type de %q = %q, want image/png", chave, falso.tipos[chave])
+		}
+
+		leitor, erro := s3.Abrir(ctx, chave)
+		if erro != nil {
+			t.Fatalf("Abrir(%q): %v", chave, erro)
+		}
+		lido, _ := io.ReadAll(leitor)
+		leitor.Close()
+		if !bytes.Equal(lido, conteudo) {
+			t.Errorf("Abrir(%q) = %q, want %q", chave, lido, conteudo)
+		}
+
+		if erro := s3.Remover(ctx, chave); erro != nil {
+			t.Fatalf("Remover(%q): %v", chave, erro)
+		}
+		if _, erro := s3.Abrir(ctx, chave); !errors.Is(erro, ErrNaoEncontrado) {
+			t.Errorf("Abrir(%q) depois de remover: erro = %v, want ErrNaoEncontrado", chave, erro)
+		}
+	}
+}
+
+func TestS3AssinaturaInvalidaFalha(t *testing.T) {
+	s3, _ := novoS3Falso(t)
+	s3.secretKey = "outro segredo"
+
+	erro := s3.Salvar(context.Background(), "anexos/1/x.png", []byte("x"), "image/png")
+	if erro == nil || !strings.Contains(erro.Error(), "403") {
+		t.Errorf("Salvar com segredo errado: erro = %v, want resposta 403", erro)
+	}
+}
+
+func TestCodificarCaminho(t *testing.T) {
+	casos := map[string]string{
+		"anexos/10/abc-1_2.~x.jpg": "anexos/10/abc-1_2.~x.jpg",
+		"a b+c":                    "a%20b%2Bc",
+		"ção":                      "%C3%A7%C3%A3o",
+	}
+	for chave, want := range casos {
+		if got := codificarCaminho(chave); got != want {
+			t.Errorf("codificarCaminho(%q) = %q, want %q", chave, got, want)
+		}
+	}
+}
+
+// A chave de assinatura é derivada como no exemplo da documentação da AWS
+func TestChaveAssinaturaDocumentacaoAWS(t *testing.T) {
+	chave := hmacSHA256([]byte("AWS4wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"), "20120215")
+	chave = hmacSHA256(chave, "us-east-1")
+	chave = hmacSHA256(chave, "iam")
+	chave = hmacSHA256(chave, "aws4_request")
+
+	want := "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d"
+	if got := hex.EncodeToString(chave); got != want {
+		t.Errorf("chave de assinatura = %s, want %s", got, want)
+	}
+}
@@ -36,7 +36,20 @@ func ValidateToken(r *http.Request) error {
 }
 
 func ExtrairUsuarioId(r *http.Request) (uint64, error) {
+	return usuarioIdDoToken(extrairToken(r))
+}
+
+// ExtrairUsuarioIdOuQuery é ExtrairUsuarioId aceitando também o token no parâmetro token da URL,
+// para clientes que não conseguem enviar cabeçalhos, como o EventSource dos navegadores
+func ExtrairUsuarioIdOuQuery(r *http.Request) (uint64, error) {
 	tokenString := extrairToken(r)
+	if tokenString == "" {
+		tokenString = r.URL.Query().Get("token")
+	}
+	return usuarioIdDoToken(tokenString)
+}
+
+func usuarioIdDoToken(tokenString string) (uint64, error) {
 	token, erro := jwt.Parse(tokenString, retornarChaveDeVerificacao)
 	if erro != nil {
 		return 0, erro
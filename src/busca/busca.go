@@ -0,0 +1,38 @@
+// Package busca encontra publicações pelo texto. A consulta é interpretada aqui e resolvida por um
+// motor: o índice FULLTEXT do MySQL ou, em desenvolvimento, um índice invertido em memória
+package busca
+
+import (
+	"api/src/config"
+	"api/src/models"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Motor resolve uma busca de publicações
+type Motor interface {
+	// Buscar retorna as publicações que têm todos os termos e passam pelos filtros, das mais
+	// relevantes para as menos, deixando de fora as que o visitante não pode ver
+	Buscar(termos []Termo, filtros models.FiltrosBusca, visitanteId uint64, paginacao models.Paginacao) ([]models.Relevancia, error)
+}
+
+// Novo cria o motor escolhido pela variável BUSCA_MOTOR
+func Novo(db *sql.DB) (Motor, error) {
+	switch config.BuscaMotor {
+	case "mysql":
+		return MotorMySQL{db: db}, nil
+	case "memoria":
+		return MotorMemoria{db: db, indice: indiceMemoria.Load()}, nil
+	default:
+		return nil, fmt.Errorf("Motor de busca desconhecido: %s", config.BuscaMotor)
+	}
+}
+
+// Iniciar mantém o índice em memória atualizado até o contexto ser cancelado. Com outro motor, não faz nada
+func Iniciar(ctx context.Context) {
+	if config.BuscaMotor != "memoria" {
+		return
+	}
+	manterIndice(ctx, config.BuscaIntervalo)
+}
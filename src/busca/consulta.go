@@ -0,0 +1,135 @@
+package busca
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// maximoTermos limita o tamanho da consulta
+	maximoTermos = 10
+	// tamanhoMinimoPrefixo evita prefixos que casam com boa parte do vocabulário
+	tamanhoMinimoPrefixo = 2
+)
+
+// Termo é uma parte da consulta que toda publicação encontrada precisa ter: uma palavra,
+// o começo de uma palavra (prefixo*) ou uma frase entre aspas, com as palavras em sequência
+type Termo struct {
+	Palavras []string
+	Prefixo  bool
+}
+
+// Frase informa se o termo tem mais de uma palavra
+func (termo Termo) Frase() bool {
+	return len(termo.Palavras) > 1
+}
+
+// casa informa se a palavra do texto, já normalizada, corresponde à palavra i do termo
+func (termo Termo) casa(i int, palavra string) bool {
+	if termo.Prefixo {
+		return strings.HasPrefix(palavra, termo.Palavras[i])
+	}
+	return palavra == termo.Palavras[i]
+}
+
+// Interpretar lê a consulta digitada pelo usuário. Palavras soltas precisam aparecer, em qualquer
+// ordem; "entre aspas" busca a frase exata e palavra* busca palavras que começam assim.
+// Maiúsculas e acentos não fazem diferença
+func Interpretar(consulta string) ([]Termo, error) {
+	termos := []Termo{}
+
+	for resto := strings.TrimSpace(consulta); resto != ""; resto = strings.TrimLeftFunc(resto, unicode.IsSpace) {
+		var trecho string
+		frase := strings.HasPrefix(resto, `"`)
+		if frase {
+			resto = resto[1:]
+			fim := strings.Index(resto, `"`)
+			if fim < 0 {
+				fim = len(resto)
+			}
+			trecho, resto = resto[:fim], strings.TrimPrefix(resto[fim:], `"`)
+		} else {
+			fim := strings.IndexFunc(resto, func(r rune) bool { return unicode.IsSpace(r) || r == '"' })
+			if fim < 0 {
+				fim = len(resto)
+			}
+			trecho, resto = resto[:fim], resto[fim:]
+		}
+
+		palavras := Palavras(trecho)
+		if len(palavras) == 0 {
+			continue
+		}
+
+		termo := Termo{Palavras: palavras}
+		if !frase && len(palavras) == 1 && strings.HasSuffix(trecho, "*") {
+			if utf8.RuneCountInString(palavras[0]) < tamanhoMinimoPrefixo {
+				return nil, errors.New("Buscas por prefixo precisam de pelo menos 2 letras antes do *")
+			}
+			termo.Prefixo = true
+		}
+		termos = append(termos, termo)
+	}
+
+	if len(termos) == 0 {
+		return nil, errors.New("Informe o que buscar em q")
+	}
+	if len(termos) > maximoTermos {
+		return nil, errors.New("A busca pode ter no máximo 10 termos")
+	}
+
+	return termos, nil
+}
+
+// token é uma palavra do texto, normalizada, com a posição em bytes do original
+type token struct {
+	palavra     string
+	inicio, fim int
+}
+
+// tokens separa o texto em palavras: sequências de letras e números
+func tokens(texto string) []token {
+	lista := []token{}
+	inicio := -1
+	for i, r := range texto {
+		letra := unicode.IsLetter(r) || unicode.IsNumber(r)
+		if letra && inicio < 0 {
+			inicio = i
+		}
+		if !letra && inicio >= 0 {
+			lista = append(lista, token{palavra: normalizar(texto[inicio:i]), inicio: inicio, fim: i})
+			inicio = -1
+		}
+	}
+	if inicio >= 0 {
+		lista = append(lista, token{palavra: normalizar(texto[inicio:]), inicio: inicio, fim: len(texto)})
+	}
+	return lista
+}
+
+// Palavras retorna as palavras normalizadas do texto, na ordem em que aparecem
+func Palavras(texto string) []string {
+	lista := tokens(texto)
+	palavras := make([]string, len(lista))
+	for i, token := range lista {
+		palavras[i] = token.palavra
+	}
+	return palavras
+}
+
+// semAcento troca as letras acentuadas mais comuns pela letra sem acento
+var semAcento = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a", "å", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n", "ý", "y", "ÿ", "y",
+)
+
+// normalizar deixa a palavra em minúsculas e sem acentos, para que "Ação" e "acao" sejam iguais
+func normalizar(palavra string) string {
+	return semAcento.Replace(strings.ToLower(palavra))
+}
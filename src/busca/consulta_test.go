@@ -0,0 +1,73 @@
+package busca
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInterpretar(t *testing.T) {
+	casos := []struct {
+		nome     string
+		consulta string
+		want     []Termo
+	}{
+		{"palavras soltas", "Go  rápido", []Termo{{Palavras: []string{"go"}}, {Palavras: []string{"rapido"}}}},
+		{"frase entre aspas", `"Ação direta" api`, []Termo{{Palavras: []string{"acao", "direta"}}, {Palavras: []string{"api"}}}},
+		{"aspas sem fechar vão até o fim", `mysql "busca em tex`, []Termo{{Palavras: []string{"mysql"}}, {Palavras: []string{"busca", "em", "tex"}}}},
+		{"aspas coladas na palavra", `go"lang"`, []Termo{{Palavras: []string{"go"}}, {Palavras: []string{"lang"}}}},
+		{"prefixo", "prog*", []Termo{{Palavras: []string{"prog"}, Prefixo: true}}},
+		{"asterisco na frase não é prefixo", `"prog*"`, []Termo{{Palavras: []string{"prog"}}}},
+		{"pontuação é ignorada", "go, sql! --", []Termo{{Palavras: []string{"go"}}, {Palavras: []string{"sql"}}}},
+		{"palavra com hífen vira frase", "e-mail", []Termo{{Palavras: []string{"e", "mail"}}}},
+		{"exatamente 10 termos", strings.Repeat("a ", 10), repetirTermo("a", 10)},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			got, erro := Interpretar(caso.consulta)
+			if erro != nil {
+				t.Fatalf("Interpretar(%q) erro: %v", caso.consulta, erro)
+			}
+			if !reflect.DeepEqual(got, caso.want) {
+				t.Errorf("Interpretar(%q) = %+v, want %+v", caso.consulta, got, caso.want)
+			}
+		})
+	}
+}
+
+func TestInterpretarInvalida(t *testing.T) {
+	casos := []struct {
+		nome     string
+		consulta string
+	}{
+		{"vazia", "   "},
+		{"só pontuação", `!! "" *`},
+		{"mais de 10 termos", strings.Repeat("a ", 11)},
+		{"prefixo curto", "a*"},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			if termos, erro := Interpretar(caso.consulta); erro == nil {
+				t.Errorf("Interpretar(%q) = %+v, want erro", caso.consulta, termos)
+			}
+		})
+	}
+}
+
+func TestPalavras(t *testing.T) {
+	got := Palavras("Olá, MUNDO! Coração 2024")
+	want := []string{"ola", "mundo", "coracao", "2024"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Palavras = %v, want %v", got, want)
+	}
+}
+
+func repetirTermo(palavra string, vezes int) []Termo {
+	termos := make([]Termo, vezes)
+	for i := range termos {
+		termos[i] = Termo{Palavras: []string{palavra}}
+	}
+	return termos
+}
@@ -0,0 +1,172 @@
+package busca
+
+import (
+	"api/src/models"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Parâmetros do BM25, a fórmula usada para pontuar os documentos do índice
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Documento é um texto a indexar. Os campos são indexados em sequência, mas uma frase
+// não casa atravessando de um campo para o outro
+type Documento struct {
+	Id     uint64
+	Campos []string
+}
+
+// Indice é um índice invertido em memória: para cada palavra, em que documentos e em que
+// posições ela aparece. Depois de criado não muda, então pode ser lido por várias goroutines
+type Indice struct {
+	ocorrencias map[string]map[uint64][]int
+	// vocabulario são as palavras do índice em ordem, para achar as que começam com um prefixo
+	vocabulario  []string
+	tamanhos     map[uint64]int
+	tamanhoMedio float64
+}
+
+// NovoIndice indexa os documentos
+func NovoIndice(documentos []Documento) *Indice {
+	indice := &Indice{
+		ocorrencias: make(map[string]map[uint64][]int),
+		tamanhos:    make(map[uint64]int, len(documentos)),
+	}
+
+	total := 0
+	for _, documento := range documentos {
+		posicao := 0
+		for _, campo := range documento.Campos {
+			for _, palavra := range Palavras(campo) {
+				if indice.ocorrencias[palavra] == nil {
+					indice.ocorrencias[palavra] = make(map[uint64][]int)
+				}
+				indice.ocorrencias[palavra][documento.Id] = append(indice.ocorrencias[palavra][documento.Id], posicao)
+				posicao++
+			}
+			// O salto de posição impede que uma frase junte o fim de um campo com o começo do outro
+			posicao++
+		}
+		indice.tamanhos[documento.Id] = posicao
+		total += posicao
+	}
+
+	for palavra := range indice.ocorrencias {
+		indice.vocabulario = append(indice.vocabulario, palavra)
+	}
+	sort.Strings(indice.vocabulario)
+
+	if len(documentos) > 0 {
+		indice.tamanhoMedio = float64(total) / float64(len(documentos))
+	}
+
+	return indice
+}
+
+// Candidatos retorna os documentos que têm todos os termos, dos mais relevantes para os menos
+func (indice *Indice) Candidatos(termos []Termo) []models.Relevancia {
+	var pontuacoes map[uint64]float64
+	for _, termo := range termos {
+		frequencias := indice.frequencias(termo)
+
+		proximas := make(map[uint64]float64)
+		idf := indice.idf(len(frequencias))
+		for documentoId, frequencia := range frequencias {
+			if pontuacoes != nil {
+				if _, ok := pontuacoes[documentoId]; !ok {
+					continue
+				}
+			}
+			proximas[documentoId] = pontuacoes[documentoId] + idf*indice.peso(documentoId, frequencia)
+		}
+		pontuacoes = proximas
+
+		if len(pontuacoes) == 0 {
+			return []models.Relevancia{}
+		}
+	}
+
+	resultados := make([]models.Relevancia, 0, len(pontuacoes))
+	for documentoId, pontuacao := range pontuacoes {
+		resultados = append(resultados, models.Relevancia{PublicacaoId: documentoId, Pontuacao: pontuacao})
+	}
+	sort.Slice(resultados, func(i, j int) bool {
+		if resultados[i].Pontuacao != resultados[j].Pontuacao {
+			return resultados[i].Pontuacao > resultados[j].Pontuacao
+		}
+		return resultados[i].PublicacaoId > resultados[j].PublicacaoId
+	})
+
+	return resultados
+}
+
+// frequencias conta quantas vezes o termo aparece em cada documento que o contém
+func (indice *Indice) frequencias(termo Termo) map[uint64]int {
+	frequencias := make(map[uint64]int)
+
+	if !termo.Frase() {
+		for _, palavra := range indice.palavras(termo) {
+			for documentoId, posicoes := range indice.ocorrencias[palavra] {
+				frequencias[documentoId] += len(posicoes)
+			}
+		}
+		return frequencias
+	}
+
+	// Numa frase, cada ocorrência da primeira palavra só conta se as seguintes vierem logo depois
+	primeira := indice.ocorrencias[termo.Palavras[0]]
+	for documentoId, posicoes := range primeira {
+		for _, posicao := range posicoes {
+			if indice.fraseEm(termo, documentoId, posicao) {
+				frequencias[documentoId]++
+			}
+		}
+	}
+	return frequencias
+}
+
+func (indice *Indice) fraseEm(termo Termo, documentoId uint64, posicao int) bool {
+	for i, palavra := range termo.Palavras[1:] {
+		posicoes := indice.ocorrencias[palavra][documentoId]
+		j := sort.SearchInts(posicoes, posicao+i+1)
+		if j == len(posicoes) || posicoes[j] != posicao+i+1 {
+			return false
+		}
+	}
+	return true
+}
+
+// palavras retorna as palavras do vocabulário que correspondem a um termo de uma palavra só
+func (indice *Indice) palavras(termo Termo) []string {
+	if !termo.Prefixo {
+		return termo.Palavras
+	}
+
+	prefixo := termo.Palavras[0]
+	palavras := []string{}
+	for i := sort.SearchStrings(indice.vocabulario, prefixo); i < len(indice.vocabulario); i++ {
+		if !strings.HasPrefix(indice.vocabulario[i], prefixo) {
+			break
+		}
+		palavras = append(palavras, indice.vocabulario[i])
+	}
+	return palavras
+}
+
+// idf valoriza os termos raros: quanto menos documentos têm o termo, mais ele pesa
+func (indice *Indice) idf(documentosComTermo int) float64 {
+	total := float64(len(indice.tamanhos))
+	quantidade := float64(documentosComTermo)
+	return math.Log(1 + (total-quantidade+0.5)/(quantidade+0.5))
+}
+
+// peso cresce com a frequência do termo no documento, cada vez menos, e é menor em documentos longos
+func (indice *Indice) peso(documentoId uint64, frequencia int) float64 {
+	tamanho := float64(indice.tamanhos[documentoId])
+	f := float64(frequencia)
+	return f * (bm25K1 + 1) / (f + bm25K1*(1-bm25B+bm25B*tamanho/indice.tamanhoMedio))
+}
@@ -0,0 +1,76 @@
+package busca
+
+import (
+	"reflect"
+	"testing"
+)
+
+func idsCandidatos(t *testing.T, indice *Indice, consulta string) []uint64 {
+	t.Helper()
+	termos, erro := Interpretar(consulta)
+	if erro != nil {
+		t.Fatalf("Interpretar(%q) erro: %v", consulta, erro)
+	}
+
+	ids := []uint64{}
+	for _, candidato := range indice.Candidatos(termos) {
+		ids = append(ids, candidato.PublicacaoId)
+	}
+	return ids
+}
+
+func TestCandidatos(t *testing.T) {
+	indice := NovoIndice([]Documento{
+		{Id: 1, Campos: []string{"Go na prática", "Programação concorrente com goroutines"}},
+		{Id: 2, Campos: []string{"Banco de dados", "Índices no MySQL e busca em texto"}},
+		{Id: 3, Campos: []string{"Go e MySQL", "Conectando Go ao banco"}},
+		{Id: 4, Campos: []string{"Texto livre", "Nada de programação aqui"}},
+	})
+
+	casos := []struct {
+		nome     string
+		consulta string
+		want     []uint64
+	}{
+		{"todas as palavras precisam aparecer", "go mysql", []uint64{3}},
+		{"nenhum documento com todas", "go busca", []uint64{}},
+		{"acentos e maiúsculas não importam", "PROGRAMACAO", []uint64{4, 1}},
+		{"frase em sequência", `"busca em texto"`, []uint64{2}},
+		{"frase fora de ordem não casa", `"texto em busca"`, []uint64{}},
+		{"frase não atravessa título e conteúdo", `"dados indices"`, []uint64{}},
+		{"frase dentro do título", `"banco de dados"`, []uint64{2}},
+		{"prefixo expande o vocabulário", "gor*", []uint64{1}},
+		{"prefixo combinado com palavra", "prog* concorrente", []uint64{1}},
+		{"palavra desconhecida", "python", []uint64{}},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			if got := idsCandidatos(t, indice, caso.consulta); !reflect.DeepEqual(got, caso.want) {
+				t.Errorf("Candidatos(%q) = %v, want %v", caso.consulta, got, caso.want)
+			}
+		})
+	}
+}
+
+func TestCandidatosOrdem(t *testing.T) {
+	indice := NovoIndice([]Documento{
+		{Id: 1, Campos: []string{"go", "uma linguagem"}},
+		{Id: 2, Campos: []string{"go", "go go"}},
+		{Id: 3, Campos: []string{"go", "uma linguagem"}},
+	})
+
+	// Mais ocorrências pesam mais; empates saem do id maior para o menor, sempre na mesma ordem
+	want := []uint64{2, 3, 1}
+	for range 5 {
+		if got := idsCandidatos(t, indice, "go"); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Candidatos(go) = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestCandidatosIndiceVazio(t *testing.T) {
+	if got := idsCandidatos(t, NovoIndice(nil), "go"); len(got) != 0 {
+		t.Errorf("Candidatos no índice vazio = %v, want vazio", got)
+	}
+}
@@ -0,0 +1,106 @@
+package busca
+
+import (
+	"api/src/database"
+	"api/src/models"
+	"api/src/repositories"
+	"context"
+	"database/sql"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// maximoCandidatos limita quantos resultados do índice em memória passam pelo filtro do banco.
+// Buscas mais amplas que isso perdem os resultados menos relevantes
+const maximoCandidatos = 1000
+
+// indiceMemoria é o índice usado por MotorMemoria, trocado inteiro a cada atualização
+var indiceMemoria atomic.Pointer[Indice]
+
+// MotorMemoria busca num índice invertido mantido em memória, sem depender do FULLTEXT do banco.
+// O índice é refeito a cada intervalo, então publicações novas ou editadas demoram até lá para
+// aparecer. Serve para desenvolvimento e bases pequenas
+type MotorMemoria struct {
+	db     *sql.DB
+	indice *Indice
+}
+
+// Buscar pontua no índice e usa o banco só para aplicar os filtros e a visibilidade
+func (motor MotorMemoria) Buscar(termos []Termo, filtros models.FiltrosBusca, visitanteId uint64, paginacao models.Paginacao) ([]models.Relevancia, error) {
+	if motor.indice == nil {
+		return []models.Relevancia{}, nil
+	}
+
+	candidatos := motor.indice.Candidatos(termos)
+	if len(candidatos) > maximoCandidatos {
+		candidatos = candidatos[:maximoCandidatos]
+	}
+
+	ids := make([]uint64, len(candidatos))
+	for i, candidato := range candidatos {
+		ids[i] = candidato.PublicacaoId
+	}
+
+	aceitas, erro := repositories.NewPublicacoesRepo(motor.db).FiltrarBusca(ids, filtros, visitanteId)
+	if erro != nil {
+		return nil, erro
+	}
+
+	resultados := []models.Relevancia{}
+	pular := int(paginacao.Offset())
+	for _, candidato := range candidatos {
+		if !aceitas[candidato.PublicacaoId] {
+			continue
+		}
+		if pular > 0 {
+			pular--
+			continue
+		}
+		resultados = append(resultados, candidato)
+		if uint64(len(resultados)) == paginacao.Limite {
+			break
+		}
+	}
+
+	return resultados, nil
+}
+
+// manterIndice refaz o índice em memória a cada intervalo. A primeira rodada é imediata
+func manterIndice(ctx context.Context, intervalo time.Duration) {
+	ticker := time.NewTicker(intervalo)
+	defer ticker.Stop()
+
+	for {
+		if erro := indexar(); erro != nil {
+			log.Printf("busca: erro ao montar o índice: %v", erro)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func indexar() error {
+	db, erro := database.Conectar()
+	if erro != nil {
+		return erro
+	}
+	defer db.Close()
+
+	publicacoes, erro := repositories.NewPublicacoesRepo(db).BuscarParaIndice()
+	if erro != nil {
+		return erro
+	}
+
+	documentos := make([]Documento, len(publicacoes))
+	for i, publicacao := range publicacoes {
+		documentos[i] = Documento{Id: publicacao.Id, Campos: []string{publicacao.Titulo, publicacao.Conteudo}}
+	}
+
+	indiceMemoria.Store(NovoIndice(documentos))
+	return nil
+}
@@ -0,0 +1,46 @@
+package busca
+
+import (
+	"api/src/models"
+	"api/src/repositories"
+	"database/sql"
+	"strings"
+	"unicode/utf8"
+)
+
+// tamanhoMinimoMySQL acompanha o innodb_ft_min_token_size padrão: palavras menores não vão
+// para o índice FULLTEXT e, exigidas, fariam a busca nunca encontrar nada
+const tamanhoMinimoMySQL = 3
+
+// MotorMySQL busca no índice FULLTEXT de título e conteúdo, no modo booleano
+type MotorMySQL struct {
+	db *sql.DB
+}
+
+// Buscar exige cada termo com +, buscando frases entre aspas e prefixos com *.
+// Palavras soltas curtas demais para o índice são ignoradas
+func (motor MotorMySQL) Buscar(termos []Termo, filtros models.FiltrosBusca, visitanteId uint64, paginacao models.Paginacao) ([]models.Relevancia, error) {
+	expressao := expressaoBooleana(termos)
+	if expressao == "" {
+		return []models.Relevancia{}, nil
+	}
+
+	return repositories.NewPublicacoesRepo(motor.db).BuscarTexto(expressao, filtros, visitanteId, paginacao)
+}
+
+// expressaoBooleana monta a consulta do modo booleano. As palavras só têm letras e números,
+// então não há como o usuário injetar operadores
+func expressaoBooleana(termos []Termo) string {
+	partes := []string{}
+	for _, termo := range termos {
+		switch {
+		case termo.Frase():
+			partes = append(partes, `+"`+strings.Join(termo.Palavras, " ")+`"`)
+		case termo.Prefixo:
+			partes = append(partes, "+"+termo.Palavras[0]+"*")
+		case utf8.RuneCountInString(termo.Palavras[0]) >= tamanhoMinimoMySQL:
+			partes = append(partes, "+"+termo.Palavras[0])
+		}
+	}
+	return strings.Join(partes, " ")
+}
@@ -0,0 +1,60 @@
+package busca
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestExpressaoBooleana(t *testing.T) {
+	casos := []struct {
+		nome     string
+		consulta string
+		want     string
+	}{
+		{"palavras exigidas", "golang mysql", "+golang +mysql"},
+		{"palavras curtas ficam de fora", "go é bom demais", "+bom +demais"},
+		{"só palavras curtas", "go é", ""},
+		{"frase mantém as curtas", `"go em sql"`, `+"go em sql"`},
+		{"prefixo curto continua", "go*", "+go*"},
+		{"operadores digitados são descartados", `+go -sql ~x <y >z (abc) @distance`, "+sql +abc +distance"},
+		{"frase de uma palavra vira palavra", `"fim" ) OR 1=1 --`, `+fim +"1 1"`},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			termos, erro := Interpretar(caso.consulta)
+			if erro != nil {
+				t.Fatalf("Interpretar(%q) erro: %v", caso.consulta, erro)
+			}
+			if got := expressaoBooleana(termos); got != caso.want {
+				t.Errorf("expressaoBooleana(%q) = %q, want %q", caso.consulta, got, caso.want)
+			}
+		})
+	}
+}
+
+func TestExpressaoBooleanaSemOperadoresDoUsuario(t *testing.T) {
+	consultas := []string{
+		`+a* -b ~c <d >e (f) @8 "g`,
+		`"frase\" com \"aspas" ) OR '1'='1`,
+		`palavra*** **prefixo "" "*" ''`,
+	}
+
+	for _, consulta := range consultas {
+		termos, erro := Interpretar(consulta)
+		if erro != nil {
+			continue
+		}
+		expressao := expressaoBooleana(termos)
+		// Fora de letras e números, só os operadores que a própria expressão monta
+		for _, r := range expressao {
+			if !unicode.IsLetter(r) && !unicode.IsNumber(r) && !strings.ContainsRune(`+*" `, r) {
+				t.Errorf("expressaoBooleana(%q) = %q tem o caractere %q", consulta, expressao, r)
+			}
+		}
+		if strings.Count(expressao, `"`)%2 != 0 {
+			t.Errorf("expressaoBooleana(%q) = %q tem aspas sem par", consulta, expressao)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package busca
+
+import (
+	"html"
+	"strings"
+)
+
+const (
+	// palavrasAntes é quantas palavras o trecho mostra antes da primeira ocorrência
+	palavrasAntes = 6
+	// palavrasTrecho é quantas palavras cabem no trecho
+	palavrasTrecho = 30
+)
+
+// Destacar retorna o trecho do texto em volta da primeira ocorrência dos termos, com cada palavra
+// encontrada entre <mark> e </mark> e o resto escapado para HTML. Cortes no texto viram reticências.
+// encontrado é false quando nenhum termo aparece no texto
+func Destacar(texto string, termos []Termo) (trecho string, encontrado bool) {
+	lista := tokens(texto)
+	marcadas := marcar(lista, termos)
+
+	primeira := -1
+	for i, marcada := range marcadas {
+		if marcada {
+			primeira = i
+			break
+		}
+	}
+	if primeira < 0 {
+		return "", false
+	}
+
+	inicio := max(0, primeira-palavrasAntes)
+	fim := min(len(lista), inicio+palavrasTrecho)
+
+	var construtor strings.Builder
+	if inicio > 0 {
+		construtor.WriteString("…")
+	}
+
+	posicao := 0
+	if inicio > 0 {
+		posicao = lista[inicio].inicio
+	}
+	for i := inicio; i < fim; i++ {
+		construtor.WriteString(html.EscapeString(texto[posicao:lista[i].inicio]))
+		palavra := html.EscapeString(texto[lista[i].inicio:lista[i].fim])
+		if marcadas[i] {
+			palavra = "<mark>" + palavra + "</mark>"
+		}
+		construtor.WriteString(palavra)
+		posicao = lista[i].fim
+	}
+
+	if fim < len(lista) {
+		construtor.WriteString("…")
+	} else {
+		construtor.WriteString(html.EscapeString(texto[posicao:]))
+	}
+
+	return construtor.String(), true
+}
+
+// marcar indica quais palavras do texto fazem parte de alguma ocorrência dos termos
+func marcar(lista []token, termos []Termo) []bool {
+	marcadas := make([]bool, len(lista))
+	for _, termo := range termos {
+		for i := 0; i+len(termo.Palavras) <= len(lista); i++ {
+			casou := true
+			for j := range termo.Palavras {
+				if !termo.casa(j, lista[i+j].palavra) {
+					casou = false
+					break
+				}
+			}
+			if casou {
+				for j := range termo.Palavras {
+					marcadas[i+j] = true
+				}
+			}
+		}
+	}
+	return marcadas
+}
@@ -0,0 +1,41 @@
+package busca
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDestacar(t *testing.T) {
+	longo := strings.Repeat("antes ", 10) + "alvo" + strings.Repeat(" depois", 40)
+
+	casos := []struct {
+		nome      string
+		texto     string
+		consulta  string
+		want      string
+		encontrou bool
+	}{
+		{"marca a palavra", "Aprendendo Go hoje", "go", "Aprendendo <mark>Go</mark> hoje", true},
+		{"sem acento casa com acento", "Uma ação rápida", "acao", "Uma <mark>ação</mark> rápida", true},
+		{"escapa o HTML", `<b>go</b> & "sql"`, "go", `&lt;b&gt;<mark>go</mark>&lt;/b&gt; &amp; &#34;sql&#34;`, true},
+		{"frase marca cada palavra", "busca em texto livre", `"em texto"`, "busca <mark>em</mark> <mark>texto</mark> livre", true},
+		{"prefixo marca a palavra inteira", "programação em Go", "prog*", "<mark>programação</mark> em Go", true},
+		{"reticências nos cortes", longo, "alvo",
+			"…antes antes antes antes antes antes <mark>alvo</mark>" + strings.Repeat(" depois", 23) + "…", true},
+		{"termo ausente", "nada aqui", "go", "", false},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			termos, erro := Interpretar(caso.consulta)
+			if erro != nil {
+				t.Fatalf("Interpretar(%q) erro: %v", caso.consulta, erro)
+			}
+
+			got, encontrou := Destacar(caso.texto, termos)
+			if got != caso.want || encontrou != caso.encontrou {
+				t.Errorf("Destacar = (%q, %v), want (%q, %v)", got, encontrou, caso.want, caso.encontrou)
+			}
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"api/src/models"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -12,6 +16,51 @@ var (
 	Porta            = 5000
 	SecretKey        []byte
 	DockerRun        = false
+	// TiposReacao são as reações aceitas nas publicações, configuráveis pela variável REACOES
+	TiposReacao = []string{"curtir", "amei", "haha", "uau", "triste", "grr"}
+	// IntervaloAgendador é de quanto em quanto tempo as publicações agendadas são verificadas
+	IntervaloAgendador = 30 * time.Second
+	// EventosIntervalo é de quanto em quanto tempo cada instância procura eventos novos para o stream
+	EventosIntervalo = 500 * time.Millisecond
+	// EventosHeartbeat é o intervalo dos comentários enviados para manter o stream aberto
+	EventosHeartbeat = 15 * time.Second
+	// EventosRetencao é por quanto tempo um cliente desconectado consegue retomar o stream
+	EventosRetencao = time.Hour
+	// EventosFila é quantos eventos podem esperar por um cliente lento antes de ele ser desconectado
+	EventosFila = 64
+	// TagsJanelaEmAlta é o período considerado no cálculo das tags em alta
+	TagsJanelaEmAlta = 24 * time.Hour
+	// TagsMeiaVida é o tempo para um uso de tag passar a valer metade no ranking
+	TagsMeiaVida = 6 * time.Hour
+	// BuscaMotor é "mysql" (índice FULLTEXT) ou "memoria" (índice em memória, para desenvolvimento)
+	BuscaMotor = "mysql"
+	// BuscaIntervalo é de quanto em quanto tempo o índice em memória é refeito
+	BuscaIntervalo = time.Minute
+	// SugestoesIntervalo é de quanto em quanto tempo as sugestões de quem seguir são recalculadas
+	SugestoesIntervalo = time.Hour
+	// SugestoesJanelaAtividade é o período de publicações que conta como atividade recente nas sugestões
+	SugestoesJanelaAtividade = 7 * 24 * time.Hour
+	// PesosFeed configuram o feed por relevância (?modo=relevancia)
+	PesosFeed = models.PesosFeed{
+		Janela:      72 * time.Hour,
+		MeiaVida:    12 * time.Hour,
+		Engajamento: 1,
+		Afinidade:   1,
+		SegundoGrau: 0.5,
+	}
+
+	// MidiaArmazenamento é "local" (diretório MidiaDiretorio) ou "s3" (variáveis S3_*)
+	MidiaArmazenamento = "local"
+	MidiaDiretorio     = "midias"
+	// MidiaTamanhoMaximo é o tamanho máximo de um envio, em bytes
+	MidiaTamanhoMaximo int64 = 5 << 20
+	// MidiaValidadeURL é por quanto tempo, no mínimo, as URLs assinadas de mídia funcionam
+	MidiaValidadeURL = time.Hour
+	S3Endpoint       = ""
+	S3Bucket         = ""
+	S3Regiao         = "us-east-1"
+	S3AccessKey      = ""
+	S3SecretKey      = ""
 )
 
 // Carregar vai preencher as variaveis de ambiente
@@ -33,4 +82,106 @@ func Carregar() {
 	ConnectionString = os.Getenv("ConnectionString")
 
 	SecretKey = []byte(os.Getenv("SECRET_KEY"))
+
+	if segundos, erro := strconv.Atoi(os.Getenv("AGENDADOR_INTERVALO")); erro == nil && segundos > 0 {
+		IntervaloAgendador = time.Duration(segundos) * time.Second
+	}
+
+	if horas, erro := strconv.Atoi(os.Getenv("TAGS_JANELA_HORAS")); erro == nil && horas > 0 {
+		TagsJanelaEmAlta = time.Duration(horas) * time.Hour
+	}
+	if horas, erro := strconv.Atoi(os.Getenv("TAGS_MEIA_VIDA_HORAS")); erro == nil && horas > 0 {
+		TagsMeiaVida = time.Duration(horas) * time.Hour
+	}
+
+	if motor := os.Getenv("BUSCA_MOTOR"); motor != "" {
+		BuscaMotor = motor
+	}
+	if segundos, erro := strconv.Atoi(os.Getenv("BUSCA_INTERVALO")); erro == nil && segundos > 0 {
+		BuscaIntervalo = time.Duration(segundos) * time.Second
+	}
+
+	if minutos, erro := strconv.Atoi(os.Getenv("SUGESTOES_INTERVALO")); erro == nil && minutos > 0 {
+		SugestoesIntervalo = time.Duration(minutos) * time.Minute
+	}
+	if dias, erro := strconv.Atoi(os.Getenv("SUGESTOES_ATIVIDADE_DIAS")); erro == nil && dias > 0 {
+		SugestoesJanelaAtividade = time.Duration(dias) * 24 * time.Hour
+	}
+
+	carregarEventos()
+	carregarMidia()
+	carregarFeed()
+
+	if reacoes := os.Getenv("REACOES"); reacoes != "" {
+		TiposReacao = carregarTiposReacao(reacoes)
+	}
+}
+
+// carregarTiposReacao lê a lista separada por vírgulas, garantindo que "curtir"
+// sempre exista, já que as curtidas são reações desse tipo
+func carregarTiposReacao(reacoes string) []string {
+	tipos := []string{"curtir"}
+	for _, tipo := range strings.Split(reacoes, ",") {
+		tipo = strings.ToLower(strings.TrimSpace(tipo))
+		if tipo != "" && !slices.Contains(tipos, tipo) {
+			tipos = append(tipos, tipo)
+		}
+	}
+	return tipos
+}
+
+func carregarEventos() {
+	if milissegundos, erro := strconv.Atoi(os.Getenv("EVENTOS_INTERVALO_MS")); erro == nil && milissegundos > 0 {
+		EventosIntervalo = time.Duration(milissegundos) * time.Millisecond
+	}
+	if segundos, erro := strconv.Atoi(os.Getenv("EVENTOS_HEARTBEAT")); erro == nil && segundos > 0 {
+		EventosHeartbeat = time.Duration(segundos) * time.Second
+	}
+	if minutos, erro := strconv.Atoi(os.Getenv("EVENTOS_RETENCAO")); erro == nil && minutos > 0 {
+		EventosRetencao = time.Duration(minutos) * time.Minute
+	}
+	if fila, erro := strconv.Atoi(os.Getenv("EVENTOS_FILA")); erro == nil && fila > 0 {
+		EventosFila = fila
+	}
+}
+
+func carregarFeed() {
+	if horas, erro := strconv.Atoi(os.Getenv("FEED_JANELA_HORAS")); erro == nil && horas > 0 {
+		PesosFeed.Janela = time.Duration(horas) * time.Hour
+	}
+	if horas, erro := strconv.Atoi(os.Getenv("FEED_MEIA_VIDA_HORAS")); erro == nil && horas > 0 {
+		PesosFeed.MeiaVida = time.Duration(horas) * time.Hour
+	}
+	if peso, erro := strconv.ParseFloat(os.Getenv("FEED_PESO_ENGAJAMENTO"), 64); erro == nil && peso >= 0 {
+		PesosFeed.Engajamento = peso
+	}
+	if peso, erro := strconv.ParseFloat(os.Getenv("FEED_PESO_AFINIDADE"), 64); erro == nil && peso >= 0 {
+		PesosFeed.Afinidade = peso
+	}
+	if peso, erro := strconv.ParseFloat(os.Getenv("FEED_PESO_SEGUNDO_GRAU"), 64); erro == nil && peso >= 0 {
+		PesosFeed.SegundoGrau = peso
+	}
+}
+
+func carregarMidia() {
+	if armazenamento := os.Getenv("MIDIA_ARMAZENAMENTO"); armazenamento != "" {
+		MidiaArmazenamento = armazenamento
+	}
+	if diretorio := os.Getenv("MIDIA_DIRETORIO"); diretorio != "" {
+		MidiaDiretorio = diretorio
+	}
+	if tamanho, erro := strconv.ParseInt(os.Getenv("MIDIA_TAMANHO_MAXIMO"), 10, 64); erro == nil && tamanho > 0 {
+		MidiaTamanhoMaximo = tamanho
+	}
+	if minutos, erro := strconv.Atoi(os.Getenv("MIDIA_VALIDADE_URL")); erro == nil && minutos > 0 {
+		MidiaValidadeURL = time.Duration(minutos) * time.Minute
+	}
+
+	S3Endpoint = os.Getenv("S3_ENDPOINT")
+	S3Bucket = os.Getenv("S3_BUCKET")
+	if regiao := os.Getenv("S3_REGIAO"); regiao != "" {
+		S3Regiao = regiao
+	}
+	S3AccessKey = os.Getenv("S3_ACCESS_KEY")
+	S3SecretKey = os.Getenv("S3_SECRET_KEY")
 }
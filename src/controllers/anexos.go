@@ -0,0 +1,346 @@
+package controllers
+
+import (
+	"api/src/armazenamento"
+	"api/src/authentication"
+	"api/src/config"
+	"api/src/database"
+	"api/src/midia"
+	"api/src/models"
+	"api/src/repositories"
+	"api/src/responses"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	anexosPorPublicacao = 4
+	ladoMiniatura       = 320
+)
+
+// @Summary		Enviar Anexo
+// @Description Anexa uma imagem (JPEG, PNG ou GIF) a uma publicação do usuário autenticado.
+// @Description O tipo é conferido pelo conteúdo, metadados como EXIF são removidos e uma miniatura é gerada
+// @Tags 	anexos
+// @Accept	multipart/form-data
+// @Produce	json
+// @Param id path int true "ID da publicação"
+// @Param arquivo formData file true "Imagem"
+// @Success	201 {object} models.Anexo
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /publicacoes/{id}/anexos [post]
+func EnviarAnexo(w http.ResponseWriter, r *http.Request) {
+	parametros := mux.Vars(r)
+
+	publicacaoId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	dados, erro := lerArquivoEnviado(w, r, "arquivo")
+	if erro != nil {
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	publicacaoExiste, erro := repositories.NewPublicacoesRepo(db).PublicacaoUsuarioExiste(publicacaoId, usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if !publicacaoExiste {
+		responses.Erro(w, http.StatusNotFound, errors.New("Publicação não encontrada"))
+		return
+	}
+
+	repositorio := repositories.NewAnexosRepo(db)
+	quantidade, erro := repositorio.Quantidade(publicacaoId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if quantidade >= anexosPorPublicacao {
+		responses.Erro(w, http.StatusBadRequest, fmt.Errorf("Uma publicação pode ter no máximo %d anexos", anexosPorPublicacao))
+		return
+	}
+
+	imagem, erro := processarImagem(w, dados, ladoMiniatura)
+	if erro != nil {
+		return
+	}
+
+	midias, erro := armazenamento.Novo()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	nome := fmt.Sprintf("anexos/%d/%s", publicacaoId, strings.ToLower(rand.Text()))
+	anexo := models.Anexo{
+		PublicacaoId:   publicacaoId,
+		Tipo:           imagem.Tipo,
+		Largura:        imagem.Largura,
+		Altura:         imagem.Altura,
+		Tamanho:        len(imagem.Conteudo),
+		Chave:          nome + "." + imagem.Extensao,
+		ChaveMiniatura: nome + "_miniatura." + imagem.Extensao,
+	}
+
+	arquivos := map[string][]byte{anexo.Chave: imagem.Conteudo, anexo.ChaveMiniatura: imagem.Miniatura}
+	if erro := salvarArquivos(r.Context(), midias, arquivos, imagem.Tipo); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	anexo.Id, erro = repositorio.Criar(anexo)
+	if erro != nil {
+		removerArquivos(midias, anexo.Chave, anexo.ChaveMiniatura)
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	anexo, erro = repositorio.BuscarPorId(anexo.Id)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusCreated, anexo)
+}
+
+// @Summary		Deletar Anexo
+// @Description Remove um anexo de uma publicação do usuário autenticado
+// @Tags 	anexos
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da publicação"
+// @Param anexoId path int true "ID do anexo"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /publicacoes/{id}/anexos/{anexoId} [delete]
+func DeletarAnexo(w http.ResponseWriter, r *http.Request) {
+	parametros := mux.Vars(r)
+
+	publicacaoId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	anexoId, erro := strconv.ParseUint(parametros["anexoId"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	publicacaoExiste, erro := repositories.NewPublicacoesRepo(db).PublicacaoUsuarioExiste(publicacaoId, usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if !publicacaoExiste {
+		responses.Erro(w, http.StatusNotFound, errors.New("Publicação não encontrada"))
+		return
+	}
+
+	repositorio := repositories.NewAnexosRepo(db)
+	anexo, erro := repositorio.BuscarPorId(anexoId)
+	if erro != nil {
+		if erro == sql.ErrNoRows {
+			responses.Erro(w, http.StatusNotFound, errors.New("Anexo não encontrado"))
+			return
+		}
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if anexo.PublicacaoId != publicacaoId {
+		responses.Erro(w, http.StatusNotFound, errors.New("Anexo não encontrado"))
+		return
+	}
+
+	if erro = repositorio.Deletar(anexoId); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if midias, erro := armazenamento.Novo(); erro == nil {
+		removerArquivos(midias, anexo.Chave, anexo.ChaveMiniatura)
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
+
+// @Summary		Baixar Mídia
+// @Description Entrega um arquivo de mídia. Não usa token: a URL assinada devolvida nos anexos é a autorização
+// @Tags 	anexos
+// @Produce	image/jpeg
+// @Produce	image/png
+// @Param chave path string true "Chave do arquivo"
+// @Param expira query int true "Momento em que a URL expira (unix)"
+// @Param assinatura query string true "Assinatura da URL"
+// @Success	200
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /midias/{chave} [get]
+func BaixarMidia(w http.ResponseWriter, r *http.Request) {
+	chave := mux.Vars(r)["chave"]
+	query := r.URL.Query()
+
+	if erro := midia.VerificarAssinatura(chave, query.Get("expira"), query.Get("assinatura")); erro != nil {
+		responses.Erro(w, http.StatusForbidden, erro)
+		return
+	}
+
+	midias, erro := armazenamento.Novo()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	arquivo, erro := midias.Abrir(r.Context(), chave)
+	if erro != nil {
+		if erro == armazenamento.ErrNaoEncontrado {
+			responses.Erro(w, http.StatusNotFound, erro)
+			return
+		}
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer arquivo.Close()
+
+	expira, _ := strconv.ParseInt(query.Get("expira"), 10, 64)
+	w.Header().Set("Content-Type", mime.TypeByExtension(path.Ext(chave)))
+	w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", max(0, expira-time.Now().Unix())))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	if _, erro := io.Copy(w, arquivo); erro != nil {
+		log.Printf("erro ao enviar mídia %s: %v", chave, erro)
+	}
+}
+
+// lerArquivoEnviado lê o campo do formulário multipart respeitando config.MidiaTamanhoMaximo.
+// Em caso de erro a resposta já foi escrita
+func lerArquivoEnviado(w http.ResponseWriter, r *http.Request, campo string) ([]byte, error) {
+	// Folga para os cabeçalhos do multipart além do próprio arquivo
+	r.Body = http.MaxBytesReader(w, r.Body, config.MidiaTamanhoMaximo+64<<10)
+
+	arquivo, cabecalho, erro := r.FormFile(campo)
+	if erro != nil {
+		var erroTamanho *http.MaxBytesError
+		if errors.As(erro, &erroTamanho) {
+			erro = fmt.Errorf("O arquivo deve ter no máximo %d bytes", config.MidiaTamanhoMaximo)
+			responses.Erro(w, http.StatusRequestEntityTooLarge, erro)
+			return nil, erro
+		}
+		responses.Erro(w, http.StatusBadRequest, fmt.Errorf("Envie a imagem no campo %s de um formulário multipart", campo))
+		return nil, erro
+	}
+	defer arquivo.Close()
+
+	if cabecalho.Size > config.MidiaTamanhoMaximo {
+		erro = fmt.Errorf("O arquivo deve ter no máximo %d bytes", config.MidiaTamanhoMaximo)
+		responses.Erro(w, http.StatusRequestEntityTooLarge, erro)
+		return nil, erro
+	}
+
+	dados, erro := io.ReadAll(arquivo)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnprocessableEntity, erro)
+		return nil, erro
+	}
+
+	return dados, nil
+}
+
+// processarImagem chama midia.Processar traduzindo os erros para status HTTP.
+// Em caso de erro a resposta já foi escrita
+func processarImagem(w http.ResponseWriter, dados []byte, lado int) (midia.Imagem, error) {
+	imagem, erro := midia.Processar(dados, lado)
+	switch {
+	case erro == midia.ErrTipoNaoSuportado:
+		responses.Erro(w, http.StatusUnsupportedMediaType, erro)
+	case erro == midia.ErrImagemGrande:
+		responses.Erro(w, http.StatusBadRequest, erro)
+	case erro != nil:
+		responses.Erro(w, http.StatusInternalServerError, erro)
+	}
+	return imagem, erro
+}
+
+// salvarArquivos grava todos os arquivos ou, se algum falhar, remove os que já foram gravados
+func salvarArquivos(ctx context.Context, midias armazenamento.Armazenamento, arquivos map[string][]byte, tipo string) error {
+	salvos := []string{}
+	for chave, conteudo := range arquivos {
+		if erro := midias.Salvar(ctx, chave, conteudo, tipo); erro != nil {
+			removerArquivos(midias, salvos...)
+			return erro
+		}
+		salvos = append(salvos, chave)
+	}
+	return nil
+}
+
+// removerArquivos apaga arquivos do armazenamento. Falhas só são registradas no log,
+// pois o registro no banco, que é o que aparece para os usuários, já foi removido
+func removerArquivos(midias armazenamento.Armazenamento, chaves ...string) {
+	for _, chave := range chaves {
+		if erro := midias.Remover(context.Background(), chave); erro != nil {
+			log.Printf("erro ao remover mídia %s: %v", chave, erro)
+		}
+	}
+}
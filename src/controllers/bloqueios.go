@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"api/src/authentication"
+	"api/src/database"
+	"api/src/models"
+	"api/src/repositories"
+	"api/src/responses"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// @Summary		Bloquear Usuário
+// @Description Bloqueia um usuário: os dois deixam de se seguir e não podem voltar a seguir, curtir, reagir, mencionar ou enviar
+// @Description mensagens um ao outro, e as publicações de cada um somem para o outro. Repetir a chamada mantém o bloqueio
+// @Tags 	bloqueios
+// @Produce	json
+// @Param id path int true "ID do usuário"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /usuarios/{id}/bloqueio [put]
+func BloquearUsuario(w http.ResponseWriter, r *http.Request) {
+	alterarRestricao(w, r, (*repositories.Bloqueios).Bloquear)
+}
+
+// @Summary		Desbloquear Usuário
+// @Description Remove o bloqueio. Os follows desfeitos ao bloquear não voltam
+// @Tags 	bloqueios
+// @Produce	json
+// @Param id path int true "ID do usuário"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /usuarios/{id}/bloqueio [delete]
+func DesbloquearUsuario(w http.ResponseWriter, r *http.Request) {
+	alterarRestricao(w, r, (*repositories.Bloqueios).Desbloquear)
+}
+
+// @Summary		Silenciar Usuário
+// @Description Esconde do feed do usuário autenticado as publicações e republicações de outro usuário, sem deixar de segui-lo
+// @Description e sem que ele saiba. Repetir a chamada mantém o usuário silenciado
+// @Tags 	bloqueios
+// @Produce	json
+// @Param id path int true "ID do usuário"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /usuarios/{id}/silencio [put]
+func SilenciarUsuario(w http.ResponseWriter, r *http.Request) {
+	alterarRestricao(w, r, (*repositories.Bloqueios).Silenciar)
+}
+
+// @Summary		Dessilenciar Usuário
+// @Description Volta a mostrar no feed as publicações do usuário
+// @Tags 	bloqueios
+// @Produce	json
+// @Param id path int true "ID do usuário"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /usuarios/{id}/silencio [delete]
+func DessilenciarUsuario(w http.ResponseWriter, r *http.Request) {
+	alterarRestricao(w, r, (*repositories.Bloqueios).Dessilenciar)
+}
+
+// @Summary		Buscar Bloqueados
+// @Description Lista os usuários bloqueados pelo usuário autenticado, dos bloqueios mais recentes para os mais antigos
+// @Tags 	bloqueios
+// @Produce	json
+// @Param pagina query int false "Página (começa em 1)"
+// @Param limite query int false "Quantidade de itens por página (máximo 100)"
+// @Success	200 {array} models.Usuario
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /bloqueados [get]
+func BuscarBloqueados(w http.ResponseWriter, r *http.Request) {
+	listarRestricoes(w, r, (*repositories.Bloqueios).BuscarBloqueados)
+}
+
+// @Summary		Buscar Silenciados
+// @Description Lista os usuários silenciados pelo usuário autenticado, dos mais recentes para os mais antigos
+// @Tags 	bloqueios
+// @Produce	json
+// @Param pagina query int false "Página (começa em 1)"
+// @Param limite query int false "Quantidade de itens por página (máximo 100)"
+// @Success	200 {array} models.Usuario
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /silenciados [get]
+func BuscarSilenciados(w http.ResponseWriter, r *http.Request) {
+	listarRestricoes(w, r, (*repositories.Bloqueios).BuscarSilenciados)
+}
+
+// alterarRestricao aplica ou remove um bloqueio ou silêncio do usuário autenticado sobre o usuário da rota
+func alterarRestricao(w http.ResponseWriter, r *http.Request, alterar func(*repositories.Bloqueios, uint64, uint64) error) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	alvoId, erro := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	if alvoId == usuarioId {
+		responses.Erro(w, http.StatusBadRequest, errors.New("Você não pode bloquear ou silenciar a você mesmo"))
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	existe, erro := repositories.NewUsuariosRepo(db).UsuarioExiste(alvoId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if !existe {
+		responses.Erro(w, http.StatusNotFound, errors.New("Usuário não encontrado"))
+		return
+	}
+
+	if erro := alterar(repositories.NewBloqueiosRepo(db), usuarioId, alvoId); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
+
+func listarRestricoes(w http.ResponseWriter, r *http.Request, listar func(*repositories.Bloqueios, uint64, models.Paginacao) ([]models.Usuario, error)) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	paginacao, erro := extrairPaginacao(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	usuarios, erro := listar(repositories.NewBloqueiosRepo(db), usuarioId, paginacao)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, usuarios)
+}
@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"api/src/authentication"
+	"api/src/busca"
+	"api/src/database"
+	"api/src/models"
+	"api/src/repositories"
+	"api/src/responses"
+	"errors"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// formatoDataBusca é o formato dos filtros desde e ate
+const formatoDataBusca = "2006-01-02"
+
+// @Summary		Buscar Publicações por Texto
+// @Description Busca no título e no conteúdo das publicações que o usuário autenticado pode ver, das mais relevantes
+// @Description para as menos. Todas as palavras precisam aparecer; use "aspas" para frases exatas e palavra* para
+// @Description prefixos. Maiúsculas e acentos não fazem diferença. Cada resultado traz um trecho com as ocorrências
+// @Description entre <mark> e </mark>
+// @Tags 	busca
+// @Produce	json
+// @Param q query string true "Texto a buscar"
+// @Param autor query int false "ID do autor"
+// @Param desde query string false "Publicadas a partir desta data (AAAA-MM-DD)"
+// @Param ate query string false "Publicadas até esta data, inclusive (AAAA-MM-DD)"
+// @Param tag query string false "Hashtag, com ou sem #"
+// @Param pagina query int false "Página (começa em 1)"
+// @Param limite query int false "Quantidade de itens por página (máximo 100)"
+// @Success	200 {array} models.PublicacaoEncontrada
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /busca/publicacoes [get]
+func BuscarPublicacoesPorTexto(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	query := r.URL.Query()
+	termos, erro := busca.Interpretar(query.Get("q"))
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	filtros, erro := extrairFiltrosBusca(query)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	paginacao, erro := extrairPaginacao(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	motor, erro := busca.Novo(db)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	relevancias, erro := motor.Buscar(termos, filtros, usuarioId, paginacao)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	ids := make([]uint64, len(relevancias))
+	for i, relevancia := range relevancias {
+		ids[i] = relevancia.PublicacaoId
+	}
+
+	publicacoes, erro := repositories.NewPublicacoesRepo(db).BuscarPorIds(ids, usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	porId := make(map[uint64]models.Publicacao, len(publicacoes))
+	for _, publicacao := range publicacoes {
+		porId[publicacao.Id] = publicacao
+	}
+
+	// A ordem é a da relevância; o que sumiu entre a busca e a leitura fica de fora
+	encontradas := []models.PublicacaoEncontrada{}
+	for _, relevancia := range relevancias {
+		publicacao, ok := porId[relevancia.PublicacaoId]
+		if !ok {
+			continue
+		}
+		encontradas = append(encontradas, models.PublicacaoEncontrada{
+			Publicacao: publicacao,
+			Trecho:     trechoBusca(publicacao, termos),
+			Pontuacao:  relevancia.Pontuacao,
+		})
+	}
+
+	responses.JSON(w, http.StatusOK, encontradas)
+}
+
+// extrairFiltrosBusca lê os filtros opcionais da busca de publicações
+func extrairFiltrosBusca(query url.Values) (models.FiltrosBusca, error) {
+	var filtros models.FiltrosBusca
+
+	if autor := query.Get("autor"); autor != "" {
+		autorId, erro := strconv.ParseUint(autor, 10, 64)
+		if erro != nil {
+			return filtros, errors.New("autor deve ser o id de um usuário")
+		}
+		filtros.AutorId = &autorId
+	}
+
+	if desde := query.Get("desde"); desde != "" {
+		data, erro := time.ParseInLocation(formatoDataBusca, desde, time.Local)
+		if erro != nil {
+			return filtros, errors.New("desde deve estar no formato AAAA-MM-DD")
+		}
+		filtros.Desde = &data
+	}
+
+	if ate := query.Get("ate"); ate != "" {
+		data, erro := time.ParseInLocation(formatoDataBusca, ate, time.Local)
+		if erro != nil {
+			return filtros, errors.New("ate deve estar no formato AAAA-MM-DD")
+		}
+		// ate inclui o dia inteiro
+		data = data.AddDate(0, 0, 1)
+		filtros.Ate = &data
+	}
+
+	if filtros.Desde != nil && filtros.Ate != nil && !filtros.Desde.Before(*filtros.Ate) {
+		return filtros, errors.New("desde deve ser anterior ou igual a ate")
+	}
+
+	filtros.Tag = models.NormalizarTag(query.Get("tag"))
+	return filtros, nil
+}
+
+// trechoBusca destaca os termos no conteúdo ou, se eles só aparecem no título, no título
+func trechoBusca(publicacao models.Publicacao, termos []busca.Termo) string {
+	if trecho, encontrado := busca.Destacar(publicacao.Conteudo, termos); encontrado {
+		return trecho
+	}
+	if trecho, encontrado := busca.Destacar(publicacao.Titulo, termos); encontrado {
+		return trecho
+	}
+	return html.EscapeString(publicacao.Conteudo)
+}
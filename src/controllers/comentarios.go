@@ -0,0 +1,326 @@
+package controllers
+
+import (
+	"api/src/authentication"
+	"api/src/database"
+	"api/src/models"
+	"api/src/repositories"
+	"api/src/responses"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// @Summary		Comentar Publicação
+// @Description Cria um comentário do usuário autenticado em uma publicação
+// @Tags 	comentarios
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da publicação"
+// @Param comentario body models.ComentarioRequest true "Dados do comentário"
+// @Success	201 {object} models.Comentario
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /publicacoes/{id}/comentarios [post]
+func CriarComentario(w http.ResponseWriter, r *http.Request) {
+	parametros := mux.Vars(r)
+
+	publicacaoId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	bodyRequest, erro := io.ReadAll(r.Body)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnprocessableEntity, erro)
+		return
+	}
+
+	var comentarioRequest models.ComentarioRequest
+	if erro = json.Unmarshal(bodyRequest, &comentarioRequest); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	comentario := models.Comentario{
+		PublicacaoId: publicacaoId,
+		AutorId:      usuarioId,
+		Conteudo:     comentarioRequest.Conteudo,
+	}
+
+	if erro := comentario.Preparar(); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	if !publicacaoVisivel(w, repositories.NewPublicacoesRepo(db), publicacaoId, usuarioId) {
+		return
+	}
+
+	repositorio := repositories.NewComentariosRepo(db)
+	comentario.Id, erro = repositorio.Criar(comentario)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	comentario, erro = repositorio.BuscarPorId(comentario.Id)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusCreated, comentario)
+}
+
+// @Summary		Buscar Comentários
+// @Description Busca os comentários de uma publicação, do mais antigo para o mais novo
+// @Tags 	comentarios
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da publicação"
+// @Param pagina query int false "Página (começa em 1)"
+// @Param limite query int false "Quantidade de itens por página (máximo 100)"
+// @Success	200 {array} models.Comentario
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /publicacoes/{id}/comentarios [get]
+func BuscarComentarios(w http.ResponseWriter, r *http.Request) {
+	parametros := mux.Vars(r)
+
+	publicacaoId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	paginacao, erro := extrairPaginacao(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	if !publicacaoVisivel(w, repositories.NewPublicacoesRepo(db), publicacaoId, usuarioId) {
+		return
+	}
+
+	repositorio := repositories.NewComentariosRepo(db)
+	comentarios, erro := repositorio.BuscarPorPublicacao(publicacaoId, paginacao)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, comentarios)
+}
+
+// @Summary		Atualizar Comentário
+// @Description Atualiza um comentário do usuário autenticado
+// @Tags 	comentarios
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da publicação"
+// @Param comentarioId path int true "ID do comentário"
+// @Param comentario body models.ComentarioRequest true "Dados atualizados do comentário"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /publicacoes/{id}/comentarios/{comentarioId} [put]
+func AtualizarComentario(w http.ResponseWriter, r *http.Request) {
+	parametros := mux.Vars(r)
+
+	publicacaoId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	comentarioId, erro := strconv.ParseUint(parametros["comentarioId"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	bodyRequest, erro := io.ReadAll(r.Body)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnprocessableEntity, erro)
+		return
+	}
+
+	var comentarioRequest models.ComentarioRequest
+	if erro = json.Unmarshal(bodyRequest, &comentarioRequest); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewComentariosRepo(db)
+	comentario, erro := repositorio.BuscarPorId(comentarioId)
+	if erro != nil {
+		if erro == sql.ErrNoRows {
+			responses.Erro(w, http.StatusNotFound, errors.New("Comentário não encontrado"))
+			return
+		}
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if comentario.PublicacaoId != publicacaoId {
+		responses.Erro(w, http.StatusNotFound, errors.New("Comentário não encontrado"))
+		return
+	}
+
+	if comentario.AutorId != usuarioId {
+		responses.Erro(w, http.StatusForbidden, errors.New("Você só pode editar os seus comentários"))
+		return
+	}
+
+	comentario.Conteudo = comentarioRequest.Conteudo
+	if erro := comentario.Preparar(); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	if erro = repositorio.Atualizar(comentario); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
+
+// @Summary		Deletar Comentário
+// @Description Deleta um comentário. Pode ser feito pelo autor do comentário ou pelo autor da publicação
+// @Tags 	comentarios
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da publicação"
+// @Param comentarioId path int true "ID do comentário"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /publicacoes/{id}/comentarios/{comentarioId} [delete]
+func DeletarComentario(w http.ResponseWriter, r *http.Request) {
+	parametros := mux.Vars(r)
+
+	publicacaoId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	comentarioId, erro := strconv.ParseUint(parametros["comentarioId"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewComentariosRepo(db)
+	comentario, erro := repositorio.BuscarPorId(comentarioId)
+	if erro != nil {
+		if erro == sql.ErrNoRows {
+			responses.Erro(w, http.StatusNotFound, errors.New("Comentário não encontrado"))
+			return
+		}
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if comentario.PublicacaoId != publicacaoId {
+		responses.Erro(w, http.StatusNotFound, errors.New("Comentário não encontrado"))
+		return
+	}
+
+	if comentario.AutorId != usuarioId {
+		autorDaPublicacao, erro := repositories.NewPublicacoesRepo(db).PublicacaoUsuarioExiste(publicacaoId, usuarioId)
+		if erro != nil {
+			responses.Erro(w, http.StatusInternalServerError, erro)
+			return
+		}
+
+		if !autorDaPublicacao {
+			responses.Erro(w, http.StatusForbidden, errors.New("Você não pode deletar este comentário"))
+			return
+		}
+	}
+
+	if erro = repositorio.Deletar(comentarioId); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
@@ -0,0 +1,256 @@
+package controllers
+
+import (
+	"api/src/armazenamento"
+	"api/src/authentication"
+	"api/src/database"
+	"api/src/midia"
+	"api/src/repositories"
+	"api/src/responses"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// imagemPerfilTipo descreve as diferenças entre avatar e banner; o fluxo de envio e remoção é o mesmo
+type imagemPerfilTipo struct {
+	pasta     string
+	tamanhos  []midia.Tamanho
+	atual     func(avatar, banner sql.NullString) sql.NullString
+	atualizar func(repositorio *repositories.Usuarios, usuarioId uint64, chave *string) error
+}
+
+var (
+	imagemAvatar = imagemPerfilTipo{
+		pasta:     "avatares",
+		tamanhos:  midia.TamanhosAvatar,
+		atual:     func(avatar, _ sql.NullString) sql.NullString { return avatar },
+		atualizar: (*repositories.Usuarios).AtualizarAvatar,
+	}
+	imagemBanner = imagemPerfilTipo{
+		pasta:     "banners",
+		tamanhos:  midia.TamanhosBanner,
+		atual:     func(_, banner sql.NullString) sql.NullString { return banner },
+		atualizar: (*repositories.Usuarios).AtualizarBanner,
+	}
+)
+
+// @Summary		Enviar Avatar
+// @Description Define o avatar do usuário autenticado. A imagem é recortada no centro e gerada nos tamanhos padrão (48, 128 e 400px)
+// @Tags 	usuarios
+// @Accept	multipart/form-data
+// @Produce	json
+// @Param id path int true "ID do usuário"
+// @Param arquivo formData file true "Imagem"
+// @Success	200 {object} models.ImagemPerfil
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /usuarios/{id}/avatar [put]
+func EnviarAvatar(w http.ResponseWriter, r *http.Request) {
+	enviarImagemPerfil(w, r, imagemAvatar)
+}
+
+// @Summary		Remover Avatar
+// @Description Remove o avatar do usuário autenticado
+// @Tags 	usuarios
+// @Produce	json
+// @Param id path int true "ID do usuário"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /usuarios/{id}/avatar [delete]
+func RemoverAvatar(w http.ResponseWriter, r *http.Request) {
+	removerImagemPerfil(w, r, imagemAvatar)
+}
+
+// @Summary		Enviar Banner
+// @Description Define o banner do perfil do usuário autenticado. A imagem é recortada na proporção 3:1 e gerada em 600x200 e 1500x500
+// @Tags 	usuarios
+// @Accept	multipart/form-data
+// @Produce	json
+// @Param id path int true "ID do usuário"
+// @Param arquivo formData file true "Imagem"
+// @Success	200 {object} models.ImagemPerfil
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /usuarios/{id}/banner [put]
+func EnviarBanner(w http.ResponseWriter, r *http.Request) {
+	enviarImagemPerfil(w, r, imagemBanner)
+}
+
+// @Summary		Remover Banner
+// @Description Remove o banner do perfil do usuário autenticado
+// @Tags 	usuarios
+// @Produce	json
+// @Param id path int true "ID do usuário"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /usuarios/{id}/banner [delete]
+func RemoverBanner(w http.ResponseWriter, r *http.Request) {
+	removerImagemPerfil(w, r, imagemBanner)
+}
+
+func enviarImagemPerfil(w http.ResponseWriter, r *http.Request, tipo imagemPerfilTipo) {
+	usuarioId, erro := usuarioDoPerfil(w, r)
+	if erro != nil {
+		return
+	}
+
+	dados, erro := lerArquivoEnviado(w, r, "arquivo")
+	if erro != nil {
+		return
+	}
+
+	perfil, erro := midia.ProcessarPerfil(dados, tipo.tamanhos)
+	switch {
+	case erro == midia.ErrTipoNaoSuportado:
+		responses.Erro(w, http.StatusUnsupportedMediaType, erro)
+		return
+	case erro == midia.ErrImagemGrande:
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	case erro != nil:
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewUsuariosRepo(db)
+	avatar, banner, erro := repositorio.BuscarImagens(usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	anterior := tipo.atual(avatar, banner)
+
+	midias, erro := armazenamento.Novo()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	// Cada envio ganha uma chave nova, assim URLs já assinadas da imagem anterior não mostram a nova
+	chave := fmt.Sprintf("%s/%d/%s.%s", tipo.pasta, usuarioId, strings.ToLower(rand.Text()), perfil.Extensao)
+	arquivos := make(map[string][]byte, len(perfil.Variantes))
+	for nome, conteudo := range perfil.Variantes {
+		arquivos[midia.ChaveVariante(chave, nome)] = conteudo
+	}
+
+	if erro := salvarArquivos(r.Context(), midias, arquivos, perfil.Tipo); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if erro := tipo.atualizar(repositorio, usuarioId, &chave); erro != nil {
+		removerArquivos(midias, chavesVariantes(chave, tipo.tamanhos)...)
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if anterior.Valid {
+		removerArquivos(midias, chavesVariantes(anterior.String, tipo.tamanhos)...)
+	}
+
+	imagem := repositories.ImagemPerfil(sql.NullString{String: chave, Valid: true}, tipo.tamanhos)
+	responses.JSON(w, http.StatusOK, imagem)
+}
+
+func removerImagemPerfil(w http.ResponseWriter, r *http.Request, tipo imagemPerfilTipo) {
+	usuarioId, erro := usuarioDoPerfil(w, r)
+	if erro != nil {
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewUsuariosRepo(db)
+	avatar, banner, erro := repositorio.BuscarImagens(usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	anterior := tipo.atual(avatar, banner)
+	if !anterior.Valid {
+		responses.JSON(w, http.StatusNoContent, nil)
+		return
+	}
+
+	if erro := tipo.atualizar(repositorio, usuarioId, nil); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if midias, erro := armazenamento.Novo(); erro == nil {
+		removerArquivos(midias, chavesVariantes(anterior.String, tipo.tamanhos)...)
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
+
+// usuarioDoPerfil confere que o {id} da rota é o do usuário autenticado, único que pode alterar as próprias imagens.
+// Em caso de erro a resposta já foi escrita
+func usuarioDoPerfil(w http.ResponseWriter, r *http.Request) (uint64, error) {
+	usuarioId, erro := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return 0, erro
+	}
+
+	usuarioIdToken, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return 0, erro
+	}
+
+	if usuarioId != usuarioIdToken {
+		erro = errors.New("Não é possível alterar as imagens de outro usuário")
+		responses.Erro(w, http.StatusForbidden, erro)
+		return 0, erro
+	}
+
+	return usuarioId, nil
+}
+
+// chavesVariantes lista as chaves de todos os tamanhos gerados para uma imagem de perfil
+func chavesVariantes(chave string, tamanhos []midia.Tamanho) []string {
+	chaves := make([]string, 0, len(tamanhos))
+	for _, tamanho := range tamanhos {
+		chaves = append(chaves, midia.ChaveVariante(chave, tamanho.Nome))
+	}
+	return chaves
+}
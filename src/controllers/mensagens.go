@@ -0,0 +1,487 @@
+package controllers
+
+import (
+	"api/src/authentication"
+	"api/src/database"
+	"api/src/models"
+	"api/src/repositories"
+	"api/src/responses"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// @Summary		Criar Conversa
+// @Description Cria uma conversa direta do usuário autenticado com os participantes informados. Com um participante a conversa
+// @Description é entre duas pessoas e, se já existir, ela é retornada com status 200. Grupos têm até 10 pessoas contando o criador.
+// @Description Quem ativou mensagensApenasSeguidos só pode ser incluído por quem ele segue
+// @Tags 	mensagens
+// @Accept	json
+// @Produce	json
+// @Param conversa body models.ConversaDiretaRequest true "Participantes e nome do grupo"
+// @Success	201 {object} models.ConversaDireta
+// @Success	200 {object} models.ConversaDireta
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /conversas [post]
+func CriarConversa(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	bodyRequest, erro := io.ReadAll(r.Body)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnprocessableEntity, erro)
+		return
+	}
+
+	var request models.ConversaDiretaRequest
+	if erro = json.Unmarshal(bodyRequest, &request); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	if erro = request.Preparar(usuarioId); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewMensagensRepo(db)
+	if !verificarDestinatarios(w, repositorio, usuarioId, request.Participantes) {
+		return
+	}
+
+	conversaId, criada, erro := repositorio.CriarConversa(usuarioId, request)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	conversa, erro := repositorio.BuscarConversa(conversaId, usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	status := http.StatusOK
+	if criada {
+		status = http.StatusCreated
+	}
+	responses.JSON(w, status, conversa)
+}
+
+// @Summary		Buscar Conversas
+// @Description Lista as conversas diretas do usuário autenticado, das com mensagens mais recentes para as mais antigas,
+// @Description com a última mensagem e quantas ele ainda não leu
+// @Tags 	mensagens
+// @Produce	json
+// @Param pagina query int false "Página (começa em 1)"
+// @Param limite query int false "Quantidade de itens por página (máximo 100)"
+// @Success	200 {array} models.ConversaDireta
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /conversas [get]
+func BuscarConversas(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	paginacao, erro := extrairPaginacao(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	conversas, erro := repositories.NewMensagensRepo(db).BuscarConversas(usuarioId, paginacao)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, conversas)
+}
+
+// @Summary		Contar Mensagens Não Lidas
+// @Description Conta as mensagens diretas não lidas do usuário autenticado e em quantas conversas elas estão
+// @Tags 	mensagens
+// @Produce	json
+// @Success	200 {object} models.ContagemMensagens
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /conversas/nao-lidas [get]
+func ContarMensagensNaoLidas(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	contagem, erro := repositories.NewMensagensRepo(db).ContarNaoLidas(usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, contagem)
+}
+
+// @Summary		Buscar Conversa Direta
+// @Description Retorna uma conversa direta do usuário autenticado
+// @Tags 	mensagens
+// @Produce	json
+// @Param id path int true "ID da conversa"
+// @Success	200 {object} models.ConversaDireta
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /conversas/{id} [get]
+func BuscarConversaDireta(w http.ResponseWriter, r *http.Request) {
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewMensagensRepo(db)
+	conversaId, usuarioId, _, _, erro := conversaDoUsuario(w, r, repositorio)
+	if erro != nil {
+		return
+	}
+
+	conversa, erro := repositorio.BuscarConversa(conversaId, usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, conversa)
+}
+
+// @Summary		Apagar Conversa
+// @Description Apaga a conversa só para o usuário autenticado. As mensagens até agora deixam de aparecer para ele
+// @Description e a conversa volta para a lista quando chegar uma mensagem nova
+// @Tags 	mensagens
+// @Produce	json
+// @Param id path int true "ID da conversa"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /conversas/{id} [delete]
+func ApagarConversa(w http.ResponseWriter, r *http.Request) {
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewMensagensRepo(db)
+	conversaId, usuarioId, _, _, erro := conversaDoUsuario(w, r, repositorio)
+	if erro != nil {
+		return
+	}
+
+	if erro := repositorio.OcultarConversa(conversaId, usuarioId); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
+
+// @Summary		Buscar Mensagens
+// @Description Lista as mensagens da conversa, das mais recentes para as mais antigas. Para buscar as anteriores,
+// @Description envie o proximoCursor da resposta no parâmetro antes
+// @Tags 	mensagens
+// @Produce	json
+// @Param id path int true "ID da conversa"
+// @Param antes query int false "Cursor: busca as mensagens anteriores a esta"
+// @Param limite query int false "Quantidade de mensagens (máximo 100)"
+// @Success	200 {object} models.PaginaMensagens
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /conversas/{id}/mensagens [get]
+func BuscarMensagens(w http.ResponseWriter, r *http.Request) {
+	antes, limite, erro := extrairCursor(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewMensagensRepo(db)
+	conversaId, usuarioId, _, _, erro := conversaDoUsuario(w, r, repositorio)
+	if erro != nil {
+		return
+	}
+
+	pagina, erro := repositorio.BuscarMensagens(conversaId, usuarioId, antes, limite)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, pagina)
+}
+
+// @Summary		Enviar Mensagem
+// @Description Envia uma mensagem na conversa. Em conversas entre duas pessoas, quem ativou mensagensApenasSeguidos
+// @Description só recebe de quem ele segue. Não é possível enviar em conversas, inclusive grupos, com alguém que
+// @Description bloqueou o usuário ou foi bloqueado por ele
+// @Tags 	mensagens
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da conversa"
+// @Param mensagem body models.MensagemRequest true "Texto da mensagem"
+// @Success	201 {object} models.Mensagem
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /conversas/{id}/mensagens [post]
+func EnviarMensagem(w http.ResponseWriter, r *http.Request) {
+	bodyRequest, erro := io.ReadAll(r.Body)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnprocessableEntity, erro)
+		return
+	}
+
+	var request models.MensagemRequest
+	if erro = json.Unmarshal(bodyRequest, &request); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	if erro = request.Preparar(); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewMensagensRepo(db)
+	conversaId, usuarioId, participantes, grupo, erro := conversaDoUsuario(w, r, repositorio)
+	if erro != nil {
+		return
+	}
+
+	// Em grupos, mensagensApenasSeguidos vale ao entrar e só o bloqueio é conferido a cada mensagem;
+	// entre duas pessoas tudo é conferido, pois o destinatário pode ter mudado a preferência depois
+	destinatarios := slices.DeleteFunc(slices.Clone(participantes), func(id uint64) bool { return id == usuarioId })
+	if grupo {
+		bloqueio, erro := repositorio.ComBloqueio(usuarioId, destinatarios)
+		if erro != nil {
+			responses.Erro(w, http.StatusInternalServerError, erro)
+			return
+		}
+		if bloqueio {
+			responses.Erro(w, http.StatusForbidden, repositories.ErrBloqueado)
+			return
+		}
+	} else if !verificarDestinatarios(w, repositorio, usuarioId, destinatarios) {
+		return
+	}
+
+	mensagem, erro := repositorio.Enviar(conversaId, usuarioId, request.Texto)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusCreated, mensagem)
+}
+
+// @Summary		Marcar Conversa Lida
+// @Description Marca como lidas todas as mensagens da conversa para o usuário autenticado
+// @Tags 	mensagens
+// @Produce	json
+// @Param id path int true "ID da conversa"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /conversas/{id}/lida [put]
+func MarcarConversaLida(w http.ResponseWriter, r *http.Request) {
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewMensagensRepo(db)
+	conversaId, usuarioId, _, _, erro := conversaDoUsuario(w, r, repositorio)
+	if erro != nil {
+		return
+	}
+
+	if erro := repositorio.MarcarLida(conversaId, usuarioId); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
+
+// @Summary		Apagar Mensagem
+// @Description Apaga a mensagem só para o usuário autenticado; os demais participantes continuam vendo
+// @Tags 	mensagens
+// @Produce	json
+// @Param id path int true "ID da conversa"
+// @Param mensagemId path int true "ID da mensagem"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /conversas/{id}/mensagens/{mensagemId} [delete]
+func ApagarMensagem(w http.ResponseWriter, r *http.Request) {
+	mensagemId, erro := strconv.ParseUint(mux.Vars(r)["mensagemId"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewMensagensRepo(db)
+	conversaId, usuarioId, _, _, erro := conversaDoUsuario(w, r, repositorio)
+	if erro != nil {
+		return
+	}
+
+	encontrada, erro := repositorio.OcultarMensagem(conversaId, mensagemId, usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	if !encontrada {
+		responses.Erro(w, http.StatusNotFound, errors.New("Mensagem não encontrada"))
+		return
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
+
+// conversaDoUsuario lê o {id} da rota e confere que o usuário autenticado participa da conversa.
+// Para quem não participa, a conversa não existe. Em caso de erro a resposta já foi escrita
+func conversaDoUsuario(w http.ResponseWriter, r *http.Request, repositorio *repositories.Mensagens) (conversaId uint64, usuarioId uint64, participantes []uint64, grupo bool, erro error) {
+	if conversaId, erro = strconv.ParseUint(mux.Vars(r)["id"], 10, 64); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	if usuarioId, erro = authentication.ExtrairUsuarioId(r); erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	participantes, grupo, erro = repositorio.Participantes(conversaId)
+	if erro == nil && !slices.Contains(participantes, usuarioId) {
+		erro = sql.ErrNoRows
+	}
+	if erro == sql.ErrNoRows {
+		responses.Erro(w, http.StatusNotFound, errors.New("Conversa não encontrada"))
+		return
+	}
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	return conversaId, usuarioId, participantes, grupo, nil
+}
+
+// verificarDestinatarios confere que todos os usuários existem e aceitam mensagens do remetente.
+// Em caso de falha a resposta já foi escrita
+func verificarDestinatarios(w http.ResponseWriter, repositorio *repositories.Mensagens, remetenteId uint64, ids []uint64) bool {
+	aceitam, erro := repositorio.Destinatarios(remetenteId, ids)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return false
+	}
+
+	for _, id := range ids {
+		aceita, existe := aceitam[id]
+		if !existe {
+			responses.Erro(w, http.StatusNotFound, errors.New("Usuário "+strconv.FormatUint(id, 10)+" não encontrado"))
+			return false
+		}
+		if !aceita {
+			responses.Erro(w, http.StatusForbidden, errors.New("Usuário "+strconv.FormatUint(id, 10)+" não aceita mensagens suas"))
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,272 @@
+package controllers
+
+import (
+	"api/src/authentication"
+	"api/src/database"
+	"api/src/models"
+	"api/src/repositories"
+	"api/src/responses"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// @Summary		Buscar Notificações
+// @Description Lista as notificações do usuário autenticado, das mais recentes para as mais antigas.
+// @Description Ações do mesmo tipo sobre o mesmo alvo são agrupadas enquanto a notificação não é lida
+// @Tags 	notificacoes
+// @Accept	json
+// @Produce	json
+// @Param naoLidas query bool false "Só as não lidas"
+// @Param pagina query int false "Página (começa em 1)"
+// @Param limite query int false "Quantidade de itens por página (máximo 100)"
+// @Success	200 {array} models.Notificacao
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /notificacoes [get]
+func BuscarNotificacoes(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	paginacao, erro := extrairPaginacao(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	apenasNaoLidas := false
+	if naoLidas := r.URL.Query().Get("naoLidas"); naoLidas != "" {
+		if apenasNaoLidas, erro = strconv.ParseBool(naoLidas); erro != nil {
+			responses.Erro(w, http.StatusBadRequest, errors.New("Parâmetro naoLidas inválido"))
+			return
+		}
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	notificacoes, erro := repositories.NewNotificacoesRepo(db).Buscar(usuarioId, apenasNaoLidas, paginacao)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, notificacoes)
+}
+
+// @Summary		Contar Notificações Não Lidas
+// @Description Conta as notificações não lidas do usuário autenticado, no total e por tipo
+// @Tags 	notificacoes
+// @Accept	json
+// @Produce	json
+// @Success	200 {object} models.ContagemNotificacoes
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /notificacoes/nao-lidas [get]
+func ContarNotificacoesNaoLidas(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	contagem, erro := repositories.NewNotificacoesRepo(db).ContarNaoLidas(usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, contagem)
+}
+
+// @Summary		Marcar Notificação como Lida
+// @Description Marca uma notificação do usuário autenticado como lida. Novas ações do mesmo tipo passam a gerar outra notificação
+// @Tags 	notificacoes
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da notificação"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /notificacoes/{id}/lida [put]
+func MarcarNotificacaoLida(w http.ResponseWriter, r *http.Request) {
+	notificacaoId, erro := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	if erro = repositories.NewNotificacoesRepo(db).MarcarLida(notificacaoId, usuarioId); erro != nil {
+		if erro == sql.ErrNoRows {
+			responses.Erro(w, http.StatusNotFound, errors.New("Notificação não encontrada"))
+			return
+		}
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
+
+// @Summary		Marcar Todas as Notificações como Lidas
+// @Description Marca como lidas todas as notificações do usuário autenticado
+// @Tags 	notificacoes
+// @Accept	json
+// @Produce	json
+// @Success	204
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /notificacoes/lidas [put]
+func MarcarTodasNotificacoesLidas(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	if erro = repositories.NewNotificacoesRepo(db).MarcarTodasLidas(usuarioId); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
+
+// @Summary		Buscar Preferências de Notificação
+// @Description Informa, para cada tipo de notificação, se o usuário autenticado quer recebê-la
+// @Tags 	notificacoes
+// @Accept	json
+// @Produce	json
+// @Success	200 {object} models.PreferenciasNotificacao
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /notificacoes/preferencias [get]
+func BuscarPreferenciasNotificacao(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	preferencias, erro := repositories.NewNotificacoesRepo(db).BuscarPreferencias(usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, preferencias)
+}
+
+// @Summary		Atualizar Preferências de Notificação
+// @Description Ativa ou desativa tipos de notificação do usuário autenticado. Só os tipos enviados são alterados.
+// @Description Tipos: seguir, curtida, reacao, comentario, mencao
+// @Tags 	notificacoes
+// @Accept	json
+// @Produce	json
+// @Param preferencias body models.PreferenciasNotificacao true "Tipo e se deve ser notificado"
+// @Success	200 {object} models.PreferenciasNotificacao
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /notificacoes/preferencias [put]
+func AtualizarPreferenciasNotificacao(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	bodyRequest, erro := io.ReadAll(r.Body)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnprocessableEntity, erro)
+		return
+	}
+
+	var preferencias models.PreferenciasNotificacao
+	if erro = json.Unmarshal(bodyRequest, &preferencias); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	if erro := preferencias.Validar(); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewNotificacoesRepo(db)
+	if erro = repositorio.AtualizarPreferencias(usuarioId, preferencias); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	preferencias, erro = repositorio.BuscarPreferencias(usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, preferencias)
+}
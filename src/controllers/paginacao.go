@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"api/src/models"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+const (
+	limitePadrao = 20
+	limiteMaximo = 100
+)
+
+// extrairPaginacao lê os parâmetros pagina e limite da query string,
+// aplicando os valores padrão quando não informados
+func extrairPaginacao(r *http.Request) (models.Paginacao, error) {
+	paginacao := models.Paginacao{Pagina: 1, Limite: limitePadrao}
+	query := r.URL.Query()
+
+	if pagina := query.Get("pagina"); pagina != "" {
+		valor, erro := strconv.ParseUint(pagina, 10, 64)
+		if erro != nil || valor == 0 {
+			return paginacao, errors.New("Parâmetro pagina inválido")
+		}
+		paginacao.Pagina = valor
+	}
+
+	if limite := query.Get("limite"); limite != "" {
+		valor, erro := strconv.ParseUint(limite, 10, 64)
+		if erro != nil || valor == 0 {
+			return paginacao, errors.New("Parâmetro limite inválido")
+		}
+		paginacao.Limite = min(valor, limiteMaximo)
+	}
+
+	return paginacao, nil
+}
+
+// extrairCursor lê os parâmetros antes e limite das listagens paginadas por cursor.
+// antes 0 começa do item mais recente
+func extrairCursor(r *http.Request) (antes uint64, limite uint64, erro error) {
+	query := r.URL.Query()
+	limite = limitePadrao
+
+	if valor := query.Get("antes"); valor != "" {
+		if antes, erro = strconv.ParseUint(valor, 10, 64); erro != nil {
+			return 0, 0, errors.New("Parâmetro antes inválido")
+		}
+	}
+
+	if valor := query.Get("limite"); valor != "" {
+		limite, erro = strconv.ParseUint(valor, 10, 64)
+		if erro != nil || limite == 0 {
+			return 0, 0, errors.New("Parâmetro limite inválido")
+		}
+		limite = min(limite, limiteMaximo)
+	}
+
+	return antes, limite, nil
+}
@@ -1,22 +1,29 @@
 package controllers
 
 import (
+	"api/src/armazenamento"
 	"api/src/authentication"
+	"api/src/config"
 	"api/src/database"
 	"api/src/models"
 	"api/src/repositories"
 	"api/src/responses"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 )
 
 // @Summary		Criar Publicação
-// @Description Cria uma nova publicação
+// @Description Cria uma nova publicação. Informe emRespostaA para responder, citacaoDe para citar outra publicação
+// @Description e status rascunho ou agendada (com publicarEm) para não publicar na hora. visibilidade seguidores
+// @Description restringe a publicação aos seguidores; mencionados, aos usuários mencionados no conteúdo
 // @Tags 	publicacoes
 // @Accept	json
 // @Produce	json
@@ -24,6 +31,7 @@ import (
 // @Success	201 {object} models.Publicacao
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 422 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
@@ -48,13 +56,8 @@ func CriarPublicacao(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	publicacao := models.Publicacao{
-		Titulo:   publicacaoRequest.Titulo,
-		Conteudo: publicacaoRequest.Conteudo,
-		AutorId:  usuarioId,
-	}
-
-	if erro := publicacao.Preparar(); erro != nil {
+	publicacao, erro := novaPublicacao(publicacaoRequest, usuarioId)
+	if erro != nil {
 		responses.Erro(w, http.StatusBadRequest, erro)
 		return
 	}
@@ -67,21 +70,82 @@ func CriarPublicacao(w http.ResponseWriter, r *http.Request) {
 	defer db.Close()
 
 	repositorio := repositories.NewPublicacoesRepo(db)
+	if publicacao.EmRespostaA != nil {
+		publicacaoRespondidaExiste, erro := repositorio.VisivelPara(*publicacao.EmRespostaA, usuarioId)
+		if erro != nil {
+			responses.Erro(w, http.StatusInternalServerError, erro)
+			return
+		}
+
+		if !publicacaoRespondidaExiste {
+			responses.Erro(w, http.StatusNotFound, errors.New("Publicação respondida não encontrada"))
+			return
+		}
+	}
+
+	if publicacao.CitacaoDe != nil {
+		publicacaoCitadaExiste, erro := repositorio.VisivelPara(*publicacao.CitacaoDe, usuarioId)
+		if erro != nil {
+			responses.Erro(w, http.StatusInternalServerError, erro)
+			return
+		}
+
+		if !publicacaoCitadaExiste {
+			responses.Erro(w, http.StatusNotFound, errors.New("Publicação citada não encontrada"))
+			return
+		}
+	}
+
 	publicacao.Id, erro = repositorio.Criar(usuarioId, publicacao)
 	if erro != nil {
 		responses.Erro(w, http.StatusInternalServerError, erro)
 		return
 	}
 
+	// Preparar só extrai os nicks; as menções devolvidas são as que existem como usuários
+	publicacao.Mencoes, erro = repositorio.BuscarMencoes(publicacao.Id)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
 	responses.JSON(w, http.StatusCreated, publicacao)
 }
 
+// novaPublicacao monta a publicação pedida por autorId, validada e pronta para gravar. Sem status,
+// ela é publicada na hora; rascunho e agendada ficam em /rascunhos até serem publicadas
+func novaPublicacao(publicacaoRequest models.PublicacaoRequest, autorId uint64) (models.Publicacao, error) {
+	publicacao := models.Publicacao{
+		Titulo:       publicacaoRequest.Titulo,
+		Conteudo:     publicacaoRequest.Conteudo,
+		AutorId:      autorId,
+		EmRespostaA:  publicacaoRequest.EmRespostaA,
+		CitacaoDe:    publicacaoRequest.CitacaoDe,
+		Status:       publicacaoRequest.Status,
+		PublicarEm:   publicacaoRequest.PublicarEm,
+		Visibilidade: publicacaoRequest.Visibilidade,
+	}
+
+	return publicacao, publicacao.Preparar()
+}
+
 // @Summary		Buscar Publicações
-// @Description Busca publicações do usuário autenticado e seus seguidores
+// @Description Busca publicações do usuário autenticado e de quem ele segue. No modo cronologico (padrão), traz
+// @Description também as republicações, da mais recente para a mais antiga. No modo relevancia, traz as publicações
+// @Description recentes de quem ele segue e de quem essas pessoas seguem, ordenadas pela idade, pelas reações e
+// @Description comentários e por quanto ele interage com o autor. A pontuação é calculada no instante de referência:
+// @Description repita o X-Feed-Referencia da primeira página nas seguintes para que a ordem não mude entre elas
 // @Tags 	publicacoes
 // @Accept	json
 // @Produce	json
+// @Param modo query string false "cronologico ou relevancia"
+// @Param referencia query string false "Instante da pontuação no modo relevancia (RFC 3339), padrão agora"
+// @Param pagina query int false "Página no modo relevancia (começa em 1)"
+// @Param limite query int false "Quantidade de itens por página no modo relevancia (máximo 100)"
 // @Success	200 {array} models.Publicacao
+// @Header 200 {string} X-Feed-Referencia "Instante usado na pontuação do modo relevancia"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
 // @Router /publicacoes [get]
@@ -92,6 +156,16 @@ func BuscarPublicacoes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	switch modo := r.URL.Query().Get("modo"); modo {
+	case "", "cronologico":
+	case "relevancia":
+		buscarFeedRelevancia(w, r, usuarioId)
+		return
+	default:
+		responses.Erro(w, http.StatusBadRequest, fmt.Errorf("Modo de feed desconhecido: %s", modo))
+		return
+	}
+
 	db, erro := database.Conectar()
 	if erro != nil {
 		responses.Erro(w, http.StatusInternalServerError, erro)
@@ -109,14 +183,50 @@ func BuscarPublicacoes(w http.ResponseWriter, r *http.Request) {
 	responses.JSON(w, http.StatusOK, publicacoes)
 }
 
+// buscarFeedRelevancia responde o feed no modo relevancia, com os pesos da configuração
+func buscarFeedRelevancia(w http.ResponseWriter, r *http.Request, usuarioId uint64) {
+	paginacao, erro := extrairPaginacao(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	// Sem referência, vale o segundo atual, que é a precisão do banco
+	referencia := time.Now().Truncate(time.Second)
+	if valor := r.URL.Query().Get("referencia"); valor != "" {
+		if referencia, erro = time.Parse(time.RFC3339, valor); erro != nil {
+			responses.Erro(w, http.StatusBadRequest, errors.New("referencia deve estar no formato RFC 3339"))
+			return
+		}
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	publicacoes, erro := repositories.NewPublicacoesRepo(db).BuscarFeedRelevancia(usuarioId, referencia, config.PesosFeed, paginacao)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	w.Header().Set("X-Feed-Referencia", referencia.Format(time.RFC3339))
+	responses.JSON(w, http.StatusOK, publicacoes)
+}
+
 // @Summary		Buscar Publicação
-// @Description Busca uma publicação por ID
+// @Description Busca uma publicação por ID. Se o usuário autenticado não puder vê-la, a resposta é 404,
+// @Description sem revelar que ela existe
 // @Tags 	publicacoes
 // @Accept	json
 // @Produce	json
 // @Param id path int true "ID da publicação"
 // @Success	200 {object} models.Publicacao
 // @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
@@ -131,6 +241,12 @@ func BuscarPublicacao(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
 	db, erro := database.Conectar()
 	if erro != nil {
 		responses.Erro(w, http.StatusInternalServerError, erro)
@@ -139,7 +255,7 @@ func BuscarPublicacao(w http.ResponseWriter, r *http.Request) {
 	defer db.Close()
 
 	repositorio := repositories.NewPublicacoesRepo(db)
-	publicacao, erro := repositorio.BuscarPorId(ID)
+	publicacao, erro := repositorio.BuscarPorId(ID, usuarioId)
 	if erro != nil {
 		if erro == sql.ErrNoRows {
 			responses.Erro(w, http.StatusNotFound, erro)
@@ -284,22 +400,40 @@ func DeletarPublicacao(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	anexos, erro := repositories.NewAnexosRepo(db).BuscarPorPublicacao(publicacaoId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
 	if erro = repositorio.Deletar(publicacaoId); erro != nil {
 		responses.Erro(w, http.StatusInternalServerError, erro)
 		return
 	}
 
+	if len(anexos) > 0 {
+		if midias, erro := armazenamento.Novo(); erro == nil {
+			for _, anexo := range anexos {
+				removerArquivos(midias, anexo.Chave, anexo.ChaveMiniatura)
+			}
+		}
+	}
+
 	responses.JSON(w, http.StatusNoContent, nil)
 }
 
 // @Summary		Buscar Publicações do Usuário
-// @Description Busca publicações de um usuário específico
+// @Description Busca publicações de um usuário específico. A publicação fixada no perfil vem primeiro, com fixada = true.
+// @Description Se um dos dois bloqueou o outro, a lista vem vazia. Contas privadas só mostram publicações ao dono e aos seguidores
 // @Tags 	publicacoes
 // @Accept	json
 // @Produce	json
 // @Param usuarioId path int true "ID do usuário"
 // @Success	200 {array} models.Publicacao
 // @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
 // @Router /usuarios/{usuarioId}/publicacoes [get]
@@ -313,6 +447,12 @@ func BuscarPublicacoesUsuario(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	visitanteId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
 	db, erro := database.Conectar()
 	if erro != nil {
 		responses.Erro(w, http.StatusInternalServerError, erro)
@@ -320,8 +460,12 @@ func BuscarPublicacoesUsuario(w http.ResponseWriter, r *http.Request) {
 	}
 	defer db.Close()
 
+	if !perfilVisivel(w, repositories.NewUsuariosRepo(db), usuarioId, visitanteId) {
+		return
+	}
+
 	repositorio := repositories.NewPublicacoesRepo(db)
-	publicacoes, erro := repositorio.BuscarPublicacoesUsuario(usuarioId)
+	publicacoes, erro := repositorio.BuscarPublicacoesUsuario(usuarioId, visitanteId)
 	if erro != nil {
 		responses.Erro(w, http.StatusInternalServerError, erro)
 		return
@@ -331,7 +475,7 @@ func BuscarPublicacoesUsuario(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary		Curtir Publicação
-// @Description Curte ou descurte uma publicação
+// @Description Curte ou descurte uma publicação. Prefira PUT e DELETE em /publicacoes/{id}/curtida, que podem ser repetidos com segurança
 // @Tags 	publicacoes
 // @Accept	json
 // @Produce	json
@@ -339,6 +483,8 @@ func BuscarPublicacoesUsuario(w http.ResponseWriter, r *http.Request) {
 // @Success	204
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
 // @Router /publicacoes/{id}/curtir [post]
@@ -366,10 +512,371 @@ func CurtirPublicacao(w http.ResponseWriter, r *http.Request) {
 	defer db.Close()
 
 	repositorio := repositories.NewPublicacoesRepo(db)
-	if erro = repositorio.CurtiPublicacao(publicacaoId, usuarioId); erro != nil {
+	if !publicacaoVisivel(w, repositorio, publicacaoId, usuarioId) {
+		return
+	}
+
+	erro = repositorio.CurtiPublicacao(publicacaoId, usuarioId)
+	if erro == repositories.ErrBloqueado {
+		responses.Erro(w, http.StatusForbidden, erro)
+		return
+	}
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
+
+const (
+	profundidadeConversaPadrao = 3
+	profundidadeConversaMaxima = 10
+)
+
+// @Summary		Buscar Conversa
+// @Description Busca uma publicação com a cadeia de publicações que ela responde e a árvore de respostas
+// @Tags 	publicacoes
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da publicação"
+// @Param profundidade query int false "Quantos níveis de respostas trazer (padrão 3, máximo 10)"
+// @Success	200 {object} models.Conversa
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /publicacoes/{id}/conversa [get]
+func BuscarConversa(w http.ResponseWriter, r *http.Request) {
+	parametros := mux.Vars(r)
+
+	publicacaoId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	profundidade := uint64(profundidadeConversaPadrao)
+	if valor := r.URL.Query().Get("profundidade"); valor != "" {
+		profundidade, erro = strconv.ParseUint(valor, 10, 64)
+		if erro != nil || profundidade == 0 {
+			responses.Erro(w, http.StatusBadRequest, errors.New("Parâmetro profundidade inválido"))
+			return
+		}
+		profundidade = min(profundidade, profundidadeConversaMaxima)
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewPublicacoesRepo(db)
+	publicacao, erro := repositorio.BuscarPorId(publicacaoId, usuarioId)
+	if erro != nil {
+		if erro == sql.ErrNoRows {
+			responses.Erro(w, http.StatusNotFound, erro)
+			return
+		}
+
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	ancestrais, erro := repositorio.BuscarAncestrais(publicacaoId, usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	respostas, erro := repositorio.BuscarRespostas(publicacaoId, profundidade, usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, models.Conversa{
+		Ancestrais: ancestrais,
+		Publicacao: publicacao,
+		Respostas:  respostas,
+	})
+}
+
+// @Summary		Republicar Publicação
+// @Description Compartilha uma publicação no feed de quem segue o usuário autenticado. Republicar de novo não tem efeito.
+// @Description Republicações de contas privadas continuam visíveis só para os seguidores do autor
+// @Tags 	publicacoes
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da publicação"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /publicacoes/{id}/republicar [post]
+func RepublicarPublicacao(w http.ResponseWriter, r *http.Request) {
+	parametros := mux.Vars(r)
+
+	publicacaoId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewPublicacoesRepo(db)
+	if !publicacaoVisivel(w, repositorio, publicacaoId, usuarioId) {
+		return
+	}
+
+	if erro = repositorio.Republicar(publicacaoId, usuarioId); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
+
+// @Summary		Desfazer Republicação
+// @Description Remove a republicação feita pelo usuário autenticado. Para desfazer uma citação, delete a publicação que cita
+// @Tags 	publicacoes
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da publicação"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /publicacoes/{id}/republicar [delete]
+func DesfazerRepublicacao(w http.ResponseWriter, r *http.Request) {
+	parametros := mux.Vars(r)
+
+	publicacaoId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewPublicacoesRepo(db)
+	if erro = repositorio.DesfazerRepublicacao(publicacaoId, usuarioId); erro != nil {
 		responses.Erro(w, http.StatusInternalServerError, erro)
 		return
 	}
 
 	responses.JSON(w, http.StatusNoContent, nil)
 }
+
+// @Summary		Adicionar Curtida
+// @Description Curte uma publicação, substituindo outra reação que o usuário tenha feito nela. Repetir a chamada
+// @Description mantém a curtida sem alterar quando ela foi feita
+// @Tags 	publicacoes
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da publicação"
+// @Success	200 {object} models.EstadoCurtida
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /publicacoes/{id}/curtida [put]
+func AdicionarCurtida(w http.ResponseWriter, r *http.Request) {
+	definirCurtida(w, r, true)
+}
+
+// @Summary		Remover Curtida
+// @Description Remove a curtida de uma publicação. Repetir a chamada mantém a publicação sem curtida
+// @Tags 	publicacoes
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da publicação"
+// @Success	200 {object} models.EstadoCurtida
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /publicacoes/{id}/curtida [delete]
+func RemoverCurtida(w http.ResponseWriter, r *http.Request) {
+	definirCurtida(w, r, false)
+}
+
+// definirCurtida leva a curtida do usuário autenticado ao estado pedido e responde com o estado final
+func definirCurtida(w http.ResponseWriter, r *http.Request, curtir bool) {
+	parametros := mux.Vars(r)
+
+	publicacaoId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewPublicacoesRepo(db)
+	if !publicacaoVisivel(w, repositorio, publicacaoId, usuarioId) {
+		return
+	}
+
+	if curtir {
+		erro = repositorio.Curtir(publicacaoId, usuarioId)
+	} else {
+		erro = repositorio.Descurtir(publicacaoId, usuarioId)
+	}
+	if erro == repositories.ErrBloqueado {
+		responses.Erro(w, http.StatusForbidden, erro)
+		return
+	}
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	estado, erro := repositorio.EstadoCurtida(publicacaoId, usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, estado)
+}
+
+// @Summary		Buscar Revisões
+// @Description Busca as versões de uma publicação, da original até a atual, com o diff de cada edição.
+// @Description Também informa se a publicação mudou depois da reação do usuário autenticado
+// @Tags 	publicacoes
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da publicação"
+// @Success	200 {object} models.HistoricoPublicacao
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /publicacoes/{id}/revisoes [get]
+func BuscarRevisoes(w http.ResponseWriter, r *http.Request) {
+	parametros := mux.Vars(r)
+
+	publicacaoId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewPublicacoesRepo(db)
+	publicacao, erro := repositorio.BuscarPorId(publicacaoId, usuarioId)
+	if erro != nil {
+		if erro == sql.ErrNoRows {
+			responses.Erro(w, http.StatusNotFound, erro)
+			return
+		}
+
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	revisoes, erro := repositorio.BuscarRevisoes(publicacao)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	historico := models.HistoricoPublicacao{
+		PublicacaoId: publicacao.Id,
+		Edicoes:      publicacao.Edicoes,
+		EditadaEm:    publicacao.EditadaEm,
+		Revisoes:     revisoes,
+	}
+
+	reacao, erro := repositories.NewReacoesRepo(db).BuscarReacaoUsuario(publicacaoId, usuarioId)
+	if erro != nil && erro != sql.ErrNoRows {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if erro == nil {
+		historico.MinhaReacaoEm = &reacao.CriadaEm
+		historico.EditadaAposMinhaReacao = publicacao.EditadaAposMinhaReacao
+	}
+
+	responses.JSON(w, http.StatusOK, historico)
+}
+
+// publicacaoVisivel responde 404 quando a publicação não existe ou o visitante não pode vê-la,
+// sem diferenciar os dois casos. Em caso de falha a resposta já foi escrita
+func publicacaoVisivel(w http.ResponseWriter, repositorio *repositories.Publicacoes, publicacaoId uint64, visitanteId uint64) bool {
+	visivel, erro := repositorio.VisivelPara(publicacaoId, visitanteId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return false
+	}
+
+	if !visivel {
+		responses.Erro(w, http.StatusNotFound, errors.New("Publicação não encontrada"))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"api/src/authentication"
+	"api/src/config"
+	"api/src/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNovaPublicacaoStatus(t *testing.T) {
+	amanha := time.Now().Add(24 * time.Hour)
+	ontem := time.Now().Add(-24 * time.Hour)
+
+	casos := []struct {
+		nome           string
+		request        models.PublicacaoRequest
+		wantStatus     string
+		wantPublicarEm bool
+		wantErro       bool
+	}{
+		{"sem status publica na hora", models.PublicacaoRequest{}, models.StatusPublicada, false, false},
+		{"rascunho", models.PublicacaoRequest{Status: models.StatusRascunho}, models.StatusRascunho, false, false},
+		{"rascunho ignora publicarEm", models.PublicacaoRequest{Status: models.StatusRascunho, PublicarEm: &amanha}, models.StatusRascunho, false, false},
+		{"agendada", models.PublicacaoRequest{Status: models.StatusAgendada, PublicarEm: &amanha}, models.StatusAgendada, true, false},
+		{"agendada sem publicarEm", models.PublicacaoRequest{Status: models.StatusAgendada}, "", false, true},
+		{"agendada no passado", models.PublicacaoRequest{Status: models.StatusAgendada, PublicarEm: &ontem}, "", false, true},
+		{"status inválido", models.PublicacaoRequest{Status: "arquivada"}, "", false, true},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			caso.request.Titulo, caso.request.Conteudo = "titulo", "conteudo"
+
+			publicacao, erro := novaPublicacao(caso.request, 1)
+			if caso.wantErro {
+				if erro == nil {
+					t.Fatalf("novaPublicacao aceitou status %q sem erro", caso.request.Status)
+				}
+				return
+			}
+			if erro != nil {
+				t.Fatal(erro)
+			}
+
+			if publicacao.Status != caso.wantStatus {
+				t.Errorf("Status = %q, want %q", publicacao.Status, caso.wantStatus)
+			}
+			if (publicacao.PublicarEm != nil) != caso.wantPublicarEm {
+				t.Errorf("PublicarEm = %v, want presente %v", publicacao.PublicarEm, caso.wantPublicarEm)
+			}
+		})
+	}
+}
+
+func TestCriarPublicacaoAgendadaSemPublicarEm(t *testing.T) {
+	segredo := config.SecretKey
+	config.SecretKey = []byte("segredo de teste")
+	t.Cleanup(func() { config.SecretKey = segredo })
+
+	token, erro := authentication.GenerateToken(1)
+	if erro != nil {
+		t.Fatal(erro)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/publicacoes",
+		strings.NewReader(`{"titulo": "titulo", "conteudo": "conteudo", "status": "agendada"}`))
+	r.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	CriarPublicacao(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d (%s)", w.Code, http.StatusBadRequest, w.Body)
+	}
+	if !strings.Contains(w.Body.String(), "publicarEm") {
+		t.Errorf("resposta %s não explica que falta publicarEm", w.Body)
+	}
+}
@@ -0,0 +1,226 @@
+package controllers
+
+import (
+	"api/src/authentication"
+	"api/src/database"
+	"api/src/models"
+	"api/src/repositories"
+	"api/src/responses"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// @Summary		Buscar Rascunhos
+// @Description Busca os rascunhos e as publicações agendadas do usuário autenticado
+// @Tags 	rascunhos
+// @Accept	json
+// @Produce	json
+// @Param pagina query int false "Página (começa em 1)"
+// @Param limite query int false "Quantidade de itens por página (máximo 100)"
+// @Success	200 {array} models.Publicacao
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /rascunhos [get]
+func BuscarRascunhos(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	paginacao, erro := extrairPaginacao(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewPublicacoesRepo(db)
+	rascunhos, erro := repositorio.BuscarRascunhos(usuarioId, paginacao)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, rascunhos)
+}
+
+// @Summary		Atualizar Rascunho
+// @Description Atualiza texto, status, data de publicação e visibilidade de um rascunho ou agendada do usuário autenticado.
+// @Description Com status publicada, o rascunho é publicado na hora
+// @Tags 	rascunhos
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID do rascunho"
+// @Param rascunho body models.PublicacaoRequest true "Dados atualizados do rascunho"
+// @Success	200 {object} models.Publicacao
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /rascunhos/{id} [put]
+func AtualizarRascunho(w http.ResponseWriter, r *http.Request) {
+	parametros := mux.Vars(r)
+
+	publicacaoId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	bodyRequest, erro := io.ReadAll(r.Body)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnprocessableEntity, erro)
+		return
+	}
+
+	var publicacaoRequest models.PublicacaoRequest
+	if erro = json.Unmarshal(bodyRequest, &publicacaoRequest); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	status := publicacaoRequest.Status
+	if status == "" {
+		status = models.StatusRascunho
+	}
+
+	publicacao := models.Publicacao{
+		Id:           publicacaoId,
+		Titulo:       publicacaoRequest.Titulo,
+		Conteudo:     publicacaoRequest.Conteudo,
+		AutorId:      usuarioId,
+		Status:       status,
+		PublicarEm:   publicacaoRequest.PublicarEm,
+		Visibilidade: publicacaoRequest.Visibilidade,
+	}
+
+	if erro := publicacao.Preparar(); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewPublicacoesRepo(db)
+	if _, erro := repositorio.BuscarRascunho(publicacaoId, usuarioId); erro != nil {
+		if erro == sql.ErrNoRows {
+			responses.Erro(w, http.StatusNotFound, errors.New("Rascunho não encontrado"))
+			return
+		}
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if erro = repositorio.AtualizarRascunho(publicacao); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responderRascunhoAtualizado(w, repositorio, publicacao)
+}
+
+// @Summary		Publicar Rascunho
+// @Description Publica na hora um rascunho ou agendada do usuário autenticado
+// @Tags 	rascunhos
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID do rascunho"
+// @Success	200 {object} models.Publicacao
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /rascunhos/{id}/publicar [post]
+func PublicarRascunho(w http.ResponseWriter, r *http.Request) {
+	parametros := mux.Vars(r)
+
+	publicacaoId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewPublicacoesRepo(db)
+	publicacao, erro := repositorio.BuscarRascunho(publicacaoId, usuarioId)
+	if erro != nil {
+		if erro == sql.ErrNoRows {
+			responses.Erro(w, http.StatusNotFound, errors.New("Rascunho não encontrado"))
+			return
+		}
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	publicacao.Status = models.StatusPublicada
+	publicacao.PublicarEm = nil
+	if erro := publicacao.Preparar(); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	if erro = repositorio.AtualizarRascunho(publicacao); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responderRascunhoAtualizado(w, repositorio, publicacao)
+}
+
+// responderRascunhoAtualizado devolve o rascunho como ficou salvo, buscando
+// entre as publicadas quando ele acabou de ser publicado
+func responderRascunhoAtualizado(w http.ResponseWriter, repositorio *repositories.Publicacoes, publicacao models.Publicacao) {
+	var erro error
+	if publicacao.Status == models.StatusPublicada {
+		publicacao, erro = repositorio.BuscarPorId(publicacao.Id, publicacao.AutorId)
+	} else {
+		publicacao, erro = repositorio.BuscarRascunho(publicacao.Id, publicacao.AutorId)
+	}
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, publicacao)
+}
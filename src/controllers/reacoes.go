@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"api/src/authentication"
+	"api/src/config"
+	"api/src/database"
+	"api/src/models"
+	"api/src/repositories"
+	"api/src/responses"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// @Summary		Reagir a Publicação
+// @Description Grava a reação do usuário autenticado na publicação, trocando a reação anterior se existir
+// @Tags 	reacoes
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da publicação"
+// @Param reacao body models.ReacaoRequest true "Tipo da reação"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /publicacoes/{id}/reacao [put]
+func ReagirPublicacao(w http.ResponseWriter, r *http.Request) {
+	parametros := mux.Vars(r)
+
+	publicacaoId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	bodyRequest, erro := io.ReadAll(r.Body)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnprocessableEntity, erro)
+		return
+	}
+
+	var reacaoRequest models.ReacaoRequest
+	if erro = json.Unmarshal(bodyRequest, &reacaoRequest); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	if erro := reacaoRequest.Preparar(config.TiposReacao); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	if !publicacaoVisivel(w, repositories.NewPublicacoesRepo(db), publicacaoId, usuarioId) {
+		return
+	}
+
+	repositorio := repositories.NewReacoesRepo(db)
+	erro = repositorio.Reagir(publicacaoId, usuarioId, reacaoRequest.Tipo)
+	if erro == repositories.ErrBloqueado {
+		responses.Erro(w, http.StatusForbidden, erro)
+		return
+	}
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
+
+// @Summary		Remover Reação
+// @Description Remove a reação do usuário autenticado na publicação
+// @Tags 	reacoes
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da publicação"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /publicacoes/{id}/reacao [delete]
+func RemoverReacao(w http.ResponseWriter, r *http.Request) {
+	parametros := mux.Vars(r)
+
+	publicacaoId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewReacoesRepo(db)
+	if erro = repositorio.Remover(publicacaoId, usuarioId); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
+
+// @Summary		Buscar Reações
+// @Description Lista quem reagiu a uma publicação, opcionalmente filtrando por tipo
+// @Tags 	reacoes
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da publicação"
+// @Param tipo query string false "Tipo da reação"
+// @Param pagina query int false "Página (começa em 1)"
+// @Param limite query int false "Quantidade de itens por página (máximo 100)"
+// @Success	200 {array} models.Reacao
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /publicacoes/{id}/reacoes [get]
+func BuscarReacoes(w http.ResponseWriter, r *http.Request) {
+	parametros := mux.Vars(r)
+
+	publicacaoId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	paginacao, erro := extrairPaginacao(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	tipo := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("tipo")))
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	if !publicacaoVisivel(w, repositories.NewPublicacoesRepo(db), publicacaoId, usuarioId) {
+		return
+	}
+
+	repositorio := repositories.NewReacoesRepo(db)
+	reacoes, erro := repositorio.BuscarPorPublicacao(publicacaoId, tipo, paginacao)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, reacoes)
+}
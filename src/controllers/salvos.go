@@ -0,0 +1,430 @@
+package controllers
+
+import (
+	"api/src/authentication"
+	"api/src/database"
+	"api/src/models"
+	"api/src/repositories"
+	"api/src/responses"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// @Summary		Salvar Publicação
+// @Description Salva a publicação para o usuário autenticado, sem avisar o autor. Com colecaoId, a publicação fica
+// @Description naquela coleção; salvar de novo só troca a coleção. O corpo é opcional
+// @Tags 	salvos
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da publicação"
+// @Param salvo body models.SalvarRequest false "Coleção onde salvar"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /publicacoes/{id}/salvo [put]
+func SalvarPublicacao(w http.ResponseWriter, r *http.Request) {
+	publicacaoId, erro := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	bodyRequest, erro := io.ReadAll(r.Body)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnprocessableEntity, erro)
+		return
+	}
+
+	var salvarRequest models.SalvarRequest
+	if len(bodyRequest) > 0 {
+		if erro = json.Unmarshal(bodyRequest, &salvarRequest); erro != nil {
+			responses.Erro(w, http.StatusBadRequest, erro)
+			return
+		}
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	if !publicacaoVisivel(w, repositories.NewPublicacoesRepo(db), publicacaoId, usuarioId) {
+		return
+	}
+
+	repositorio := repositories.NewSalvosRepo(db)
+	if salvarRequest.ColecaoId != nil {
+		if _, ok := colecaoDoUsuario(w, repositorio, *salvarRequest.ColecaoId, usuarioId); !ok {
+			return
+		}
+	}
+
+	if erro = repositorio.Salvar(usuarioId, publicacaoId, salvarRequest.ColecaoId); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
+
+// @Summary		Remover Publicação Salva
+// @Description Tira a publicação das salvas do usuário autenticado. Repetir a chamada não tem efeito
+// @Tags 	salvos
+// @Produce	json
+// @Param id path int true "ID da publicação"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /publicacoes/{id}/salvo [delete]
+func RemoverSalvo(w http.ResponseWriter, r *http.Request) {
+	publicacaoId, erro := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	if erro = repositories.NewSalvosRepo(db).RemoverSalvo(usuarioId, publicacaoId); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
+
+// @Summary		Buscar Salvos
+// @Description Lista as publicações salvas pelo usuário autenticado, das salvas mais recentemente para as mais antigas.
+// @Description Informe colecao para ver só as de uma coleção
+// @Tags 	salvos
+// @Produce	json
+// @Param colecao query int false "ID da coleção"
+// @Param pagina query int false "Página (começa em 1)"
+// @Param limite query int false "Quantidade de itens por página (máximo 100)"
+// @Success	200 {array} models.Publicacao
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /salvos [get]
+func BuscarSalvos(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	paginacao, erro := extrairPaginacao(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	var colecaoId *uint64
+	if colecao := r.URL.Query().Get("colecao"); colecao != "" {
+		id, erro := strconv.ParseUint(colecao, 10, 64)
+		if erro != nil {
+			responses.Erro(w, http.StatusBadRequest, errors.New("colecao deve ser um número"))
+			return
+		}
+		colecaoId = &id
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewSalvosRepo(db)
+	if colecaoId != nil {
+		if _, ok := colecaoDoUsuario(w, repositorio, *colecaoId, usuarioId); !ok {
+			return
+		}
+	}
+
+	publicacoes, erro := repositorio.BuscarSalvos(usuarioId, colecaoId, paginacao)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, publicacoes)
+}
+
+// @Summary		Buscar Coleções
+// @Description Lista as coleções de publicações salvas do usuário autenticado, em ordem alfabética
+// @Tags 	salvos
+// @Produce	json
+// @Success	200 {array} models.Colecao
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /colecoes [get]
+func BuscarColecoes(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	colecoes, erro := repositories.NewSalvosRepo(db).BuscarColecoes(usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, colecoes)
+}
+
+// @Summary		Criar Coleção
+// @Description Cria uma coleção vazia para organizar as publicações salvas. O nome não pode repetir o de outra coleção do usuário
+// @Tags 	salvos
+// @Accept	json
+// @Produce	json
+// @Param colecao body models.ColecaoRequest true "Nome da coleção"
+// @Success	201 {object} models.Colecao
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /colecoes [post]
+func CriarColecao(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	colecaoRequest, ok := lerColecao(w, r)
+	if !ok {
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewSalvosRepo(db)
+	if !nomeColecaoLivre(w, repositorio, usuarioId, colecaoRequest.Nome, 0) {
+		return
+	}
+
+	colecaoId, erro := repositorio.CriarColecao(usuarioId, colecaoRequest.Nome)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	colecao, erro := repositorio.BuscarColecao(colecaoId, usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusCreated, colecao)
+}
+
+// @Summary		Renomear Coleção
+// @Description Troca o nome de uma coleção do usuário autenticado
+// @Tags 	salvos
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID da coleção"
+// @Param colecao body models.ColecaoRequest true "Novo nome da coleção"
+// @Success	200 {object} models.Colecao
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /colecoes/{id} [put]
+func RenomearColecao(w http.ResponseWriter, r *http.Request) {
+	colecaoId, erro := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	colecaoRequest, ok := lerColecao(w, r)
+	if !ok {
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewSalvosRepo(db)
+	colecao, ok := colecaoDoUsuario(w, repositorio, colecaoId, usuarioId)
+	if !ok {
+		return
+	}
+
+	if !nomeColecaoLivre(w, repositorio, usuarioId, colecaoRequest.Nome, colecaoId) {
+		return
+	}
+
+	if erro = repositorio.RenomearColecao(colecaoId, usuarioId, colecaoRequest.Nome); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	colecao.Nome = colecaoRequest.Nome
+	responses.JSON(w, http.StatusOK, colecao)
+}
+
+// @Summary		Deletar Coleção
+// @Description Apaga uma coleção do usuário autenticado. As publicações dela continuam salvas, fora de coleções
+// @Tags 	salvos
+// @Produce	json
+// @Param id path int true "ID da coleção"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /colecoes/{id} [delete]
+func DeletarColecao(w http.ResponseWriter, r *http.Request) {
+	colecaoId, erro := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewSalvosRepo(db)
+	if _, ok := colecaoDoUsuario(w, repositorio, colecaoId, usuarioId); !ok {
+		return
+	}
+
+	if erro = repositorio.DeletarColecao(colecaoId, usuarioId); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
+
+// lerColecao lê e valida o corpo com o nome da coleção, respondendo o erro se houver
+func lerColecao(w http.ResponseWriter, r *http.Request) (models.ColecaoRequest, bool) {
+	var colecaoRequest models.ColecaoRequest
+
+	bodyRequest, erro := io.ReadAll(r.Body)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnprocessableEntity, erro)
+		return colecaoRequest, false
+	}
+
+	if erro = json.Unmarshal(bodyRequest, &colecaoRequest); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return colecaoRequest, false
+	}
+
+	if erro = colecaoRequest.Preparar(); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return colecaoRequest, false
+	}
+
+	return colecaoRequest, true
+}
+
+// colecaoDoUsuario busca a coleção e responde 404 se ela não existir ou for de outro usuário
+func colecaoDoUsuario(w http.ResponseWriter, repositorio *repositories.Salvos, colecaoId uint64, usuarioId uint64) (models.Colecao, bool) {
+	colecao, erro := repositorio.BuscarColecao(colecaoId, usuarioId)
+	if erro == sql.ErrNoRows {
+		responses.Erro(w, http.StatusNotFound, errors.New("Coleção não encontrada"))
+		return colecao, false
+	}
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return colecao, false
+	}
+
+	return colecao, true
+}
+
+// nomeColecaoLivre responde 409 se o usuário já tiver outra coleção com o nome
+func nomeColecaoLivre(w http.ResponseWriter, repositorio *repositories.Salvos, usuarioId uint64, nome string, exceto uint64) bool {
+	emUso, erro := repositorio.NomeEmUso(usuarioId, nome, exceto)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return false
+	}
+
+	if emUso {
+		responses.Erro(w, http.StatusConflict, errors.New("Já existe uma coleção com esse nome"))
+		return false
+	}
+
+	return true
+}
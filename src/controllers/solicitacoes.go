@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"api/src/authentication"
+	"api/src/database"
+	"api/src/repositories"
+	"api/src/responses"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// @Summary		Buscar Solicitações
+// @Description Lista quem pediu para seguir o usuário autenticado, das solicitações mais antigas para as mais recentes
+// @Tags 	usuarios
+// @Produce	json
+// @Param pagina query int false "Página (começa em 1)"
+// @Param limite query int false "Quantidade de itens por página (máximo 100)"
+// @Success	200 {array} models.Usuario
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /solicitacoes [get]
+func BuscarSolicitacoes(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	paginacao, erro := extrairPaginacao(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	usuarios, erro := repositories.NewUsuariosRepo(db).BuscarSolicitacoes(usuarioId, paginacao)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, usuarios)
+}
+
+// @Summary		Aceitar Solicitação
+// @Description Aceita o pedido do usuário da rota para seguir o usuário autenticado
+// @Tags 	usuarios
+// @Produce	json
+// @Param id path int true "ID de quem pediu para seguir"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /solicitacoes/{id} [put]
+func AceitarSolicitacao(w http.ResponseWriter, r *http.Request) {
+	responderSolicitacao(w, r, (*repositories.Usuarios).AceitarSolicitacao)
+}
+
+// @Summary		Recusar Solicitação
+// @Description Recusa o pedido do usuário da rota para seguir o usuário autenticado. Quem pediu não é avisado
+// @Tags 	usuarios
+// @Produce	json
+// @Param id path int true "ID de quem pediu para seguir"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /solicitacoes/{id} [delete]
+func RecusarSolicitacao(w http.ResponseWriter, r *http.Request) {
+	responderSolicitacao(w, r, (*repositories.Usuarios).RecusarSolicitacao)
+}
+
+func responderSolicitacao(w http.ResponseWriter, r *http.Request, responder func(*repositories.Usuarios, uint64, uint64) (bool, error)) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	solicitanteId, erro := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	encontrada, erro := responder(repositories.NewUsuariosRepo(db), usuarioId, solicitanteId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if !encontrada {
+		responses.Erro(w, http.StatusNotFound, errors.New("Solicitação não encontrada"))
+		return
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
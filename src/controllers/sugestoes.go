@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"api/src/authentication"
+	"api/src/database"
+	"api/src/repositories"
+	"api/src/responses"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// @Summary		Sugestões de Quem Seguir
+// @Description Recomenda contas para o usuário autenticado seguir: seguidas por quem ele segue, que já o seguem
+// @Description e que publicaram recentemente. As sugestões são recalculadas periodicamente; quem ele passou a
+// @Description seguir, bloqueou ou dispensou desde então já não aparece
+// @Tags 	usuarios
+// @Produce	json
+// @Param pagina query int false "Página (começa em 1)"
+// @Param limite query int false "Quantidade de itens por página (máximo 100)"
+// @Success	200 {array} models.Sugestao
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /usuarios/sugestoes [get]
+func BuscarSugestoes(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	paginacao, erro := extrairPaginacao(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	sugestoes, erro := repositories.NewUsuariosRepo(db).BuscarSugestoes(usuarioId, paginacao)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, sugestoes)
+}
+
+// @Summary		Dispensar Sugestão
+// @Description Tira a conta das sugestões do usuário autenticado, inclusive dos próximos cálculos
+// @Tags 	usuarios
+// @Param id path int true "ID do usuário sugerido"
+// @Success	204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /usuarios/sugestoes/{id} [delete]
+func DispensarSugestao(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	sugeridoId, erro := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	if sugeridoId == usuarioId {
+		responses.Erro(w, http.StatusBadRequest, errors.New("Você não pode dispensar a você mesmo"))
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewUsuariosRepo(db)
+	existe, erro := repositorio.UsuarioExiste(sugeridoId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if !existe {
+		responses.Erro(w, http.StatusNotFound, errors.New("Usuário não encontrado"))
+		return
+	}
+
+	if erro := repositorio.DispensarSugestao(usuarioId, sugeridoId); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusNoContent, nil)
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"api/src/authentication"
+	"api/src/config"
+	"api/src/database"
+	"api/src/models"
+	"api/src/repositories"
+	"api/src/responses"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// @Summary		Buscar Publicações da Tag
+// @Description Busca as publicações que usam a hashtag, das mais recentes para as mais antigas. A tag pode vir com ou sem #
+// @Tags 	tags
+// @Accept	json
+// @Produce	json
+// @Param tag path string true "Tag"
+// @Param pagina query int false "Página (começa em 1)"
+// @Param limite query int false "Quantidade de itens por página (máximo 100)"
+// @Success	200 {array} models.Publicacao
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /tags/{tag}/publicacoes [get]
+func BuscarPublicacoesTag(w http.ResponseWriter, r *http.Request) {
+	tag := models.NormalizarTag(mux.Vars(r)["tag"])
+	if tag == "" {
+		responses.Erro(w, http.StatusBadRequest, errors.New("Tag inválida"))
+		return
+	}
+
+	paginacao, erro := extrairPaginacao(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	publicacoes, erro := repositories.NewTagsRepo(db).BuscarPublicacoes(tag, usuarioId, paginacao)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, publicacoes)
+}
+
+// @Summary		Tags em Alta
+// @Description Lista as tags mais usadas nas publicações recentes. Só entram publicações da janela configurada
+// @Description (TAGS_JANELA_HORAS, padrão 24) e cada uso perde metade do peso a cada TAGS_MEIA_VIDA_HORAS (padrão 6)
+// @Tags 	tags
+// @Accept	json
+// @Produce	json
+// @Param limite query int false "Quantidade de tags (máximo 100)"
+// @Success	200 {array} models.TagEmAlta
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /tags/trending [get]
+func BuscarTagsEmAlta(w http.ResponseWriter, r *http.Request) {
+	paginacao, erro := extrairPaginacao(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	tags, erro := repositories.NewTagsRepo(db).BuscarEmAlta(time.Now(), config.TagsJanelaEmAlta, config.TagsMeiaVida, paginacao.Limite)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, tags)
+}
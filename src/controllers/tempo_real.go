@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"api/src/authentication"
+	"api/src/config"
+	"api/src/database"
+	"api/src/eventos"
+	"api/src/models"
+	"api/src/repositories"
+	"api/src/responses"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// limiteRetomada é quantos eventos perdidos um cliente pode recuperar ao reconectar.
+// Acima disso ele recebe EventoReiniciar e recarrega tudo
+const limiteRetomada = 1000
+
+// @Summary		Acompanhar Eventos
+// @Description Stream (Server-Sent Events) com as publicações novas de quem o usuário segue, as mudanças na contagem de reações
+// @Description dessas publicações, as notificações e as mensagens diretas novas. Cada evento traz id, event (publicacao, reacoes,
+// @Description notificacao, mensagem ou reiniciar)
+// @Description e data em JSON. Para retomar, envie o id do último evento recebido no cabeçalho Last-Event-ID (o EventSource faz isso
+// @Description sozinho) ou em ultimoId; se não der para retomar, chega um evento reiniciar e o cliente deve recarregar o feed.
+// @Description O token pode ir no parâmetro token para clientes que não enviam cabeçalhos. Seguir, deixar de seguir,
+// @Description silenciar ou bloquear alguém vale para a conexão aberta, sem precisar reconectar
+// @Tags 	eventos
+// @Produce	text/event-stream
+// @Param token query string false "Token JWT, quando não for possível enviar o cabeçalho Authorization"
+// @Param ultimoId query int false "Id do último evento recebido"
+// @Success	200 {object} models.Evento
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /eventos [get]
+func AcompanharEventos(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioIdOuQuery(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	var ultimoId uint64
+	if parametro := primeiroPreenchido(r.Header.Get("Last-Event-ID"), r.URL.Query().Get("ultimoId")); parametro != "" {
+		if ultimoId, erro = strconv.ParseUint(parametro, 10, 64); erro != nil {
+			responses.Erro(w, http.StatusBadRequest, errors.New("Id do último evento inválido"))
+			return
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		responses.Erro(w, http.StatusInternalServerError, errors.New("Streaming não suportado"))
+		return
+	}
+
+	seguindo, erro := buscarSeguindo(usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	// A assinatura é aberta antes de buscar os eventos perdidos, assim nada que chegue durante
+	// a busca se perde; o que vier repetido é descartado pelo id
+	assinatura := eventos.Assinar(usuarioId, seguindo)
+	defer eventos.Cancelar(assinatura)
+
+	enviados := make(map[uint64]bool)
+	var perdidos []models.Evento
+	retomar := true
+	if ultimoId > 0 {
+		if perdidos, retomar, erro = eventos.Desde(ultimoId, limiteRetomada); erro != nil {
+			responses.Erro(w, http.StatusInternalServerError, erro)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if !retomar {
+		fmt.Fprintf(w, "event: %s\ndata: {}\n\n", models.EventoReiniciar)
+	}
+	for _, evento := range perdidos {
+		// Quem o usuário segue já foi lido agora, então as mudanças de relação perdidas não importam
+		if evento.Tipo == models.EventoRelacao || !evento.Destinado(usuarioId, seguindo) {
+			continue
+		}
+		if erro := escreverEvento(w, evento); erro != nil {
+			return
+		}
+		enviados[evento.Id] = true
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(config.EventosHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case evento, aberta := <-assinatura.Eventos():
+			if !aberta {
+				// a fila encheu: o cliente reconecta e retoma do último id recebido
+				return
+			}
+			if enviados[evento.Id] {
+				continue
+			}
+			if evento.Tipo == models.EventoRelacao {
+				if seguindo, erro = buscarSeguindo(usuarioId); erro != nil {
+					return
+				}
+				eventos.Atualizar(assinatura, seguindo)
+				continue
+			}
+			// O que entrou na fila antes da atualização foi filtrado com os autores antigos
+			if !evento.Destinado(usuarioId, seguindo) {
+				continue
+			}
+			if erro := escreverEvento(w, evento); erro != nil {
+				return
+			}
+			flusher.Flush()
+
+		case <-heartbeat.C:
+			if _, erro := io.WriteString(w, ": ping\n\n"); erro != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// buscarSeguindo lê os autores cujas publicações chegam ao usuário em tempo real
+func buscarSeguindo(usuarioId uint64) (map[uint64]bool, error) {
+	db, erro := database.Conectar()
+	if erro != nil {
+		return nil, erro
+	}
+	defer db.Close()
+
+	return repositories.NewUsuariosRepo(db).IdsSeguindo(usuarioId)
+}
+
+func escreverEvento(w io.Writer, evento models.Evento) error {
+	dados, erro := json.Marshal(evento)
+	if erro != nil {
+		return erro
+	}
+
+	_, erro = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", evento.Id, evento.Tipo, dados)
+	return erro
+}
+
+func primeiroPreenchido(valores ...string) string {
+	for _, valor := range valores {
+		if valor != "" {
+			return valor
+		}
+	}
+	return ""
+}
@@ -1,8 +1,10 @@
 package controllers
 
 import (
+	"api/src/armazenamento"
 	"api/src/authentication"
 	"api/src/database"
+	"api/src/midia"
 	"api/src/models"
 	"api/src/repositories"
 	"api/src/responses"
@@ -74,17 +76,35 @@ func CriarUsuario(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary		Listar Usuários
-// @Description Lista usuários, opcionalmente filtrando por nome ou nick
+// @Description Busca usuários pelo nome ou nick, aceitando o começo, um trecho ou pequenos erros de digitação
+// @Description (a primeira letra precisa estar certa). O nick exato vem primeiro, depois quem o usuário autenticado
+// @Description segue, quem o segue e então os mais parecidos com o termo. Quem tem bloqueio com o usuário não aparece
 // @Tags 	usuarios
 // @Accept	json
 // @Produce	json
-// @Param usuario query string false "Nome ou nick do usuário"
+// @Param usuario query string false "Nome ou nick do usuário, com ou sem @"
+// @Param pagina query int false "Página (começa em 1)"
+// @Param limite query int false "Quantidade de itens por página (máximo 100)"
 // @Success	200 {array} models.Usuario
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
 // @Router /usuarios [get]
 func ListarUsuarios(w http.ResponseWriter, r *http.Request) {
-	nomeOuNick := strings.ToLower(r.URL.Query().Get("usuario"))
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	paginacao, erro := extrairPaginacao(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	nomeOuNick := strings.TrimPrefix(strings.TrimSpace(r.URL.Query().Get("usuario")), "@")
 
 	db, erro := database.Conectar()
 	if erro != nil {
@@ -94,8 +114,59 @@ func ListarUsuarios(w http.ResponseWriter, r *http.Request) {
 	defer db.Close()
 
 	repositorio := repositories.NewUsuariosRepo(db)
-	usuarios, erro := repositorio.Buscar(nomeOuNick)
+	usuarios, erro := repositorio.BuscarUsuarios(nomeOuNick, usuarioId, paginacao)
+
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, usuarios)
+}
+
+// @Summary		Autocompletar Usuários
+// @Description Sugere usuários cujo nick ou nome começa com o prefixo, para completar @menções. Traz só id, nome,
+// @Description nick e avatar, com o nick exato primeiro, depois quem o usuário autenticado segue e quem o segue
+// @Tags 	usuarios
+// @Produce	json
+// @Param prefixo query string true "Começo do nick ou do nome, com ou sem @"
+// @Param limite query int false "Quantidade de sugestões (padrão e máximo 20)"
+// @Success	200 {array} models.Usuario
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /usuarios/autocompletar [get]
+func AutocompletarUsuarios(w http.ResponseWriter, r *http.Request) {
+	usuarioId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	prefixo := strings.TrimPrefix(strings.TrimSpace(r.URL.Query().Get("prefixo")), "@")
+	if prefixo == "" {
+		responses.Erro(w, http.StatusBadRequest, errors.New("Informe o prefixo a completar"))
+		return
+	}
+
+	limite := uint64(20)
+	if valor := r.URL.Query().Get("limite"); valor != "" {
+		limite, erro = strconv.ParseUint(valor, 10, 64)
+		if erro != nil || limite == 0 {
+			responses.Erro(w, http.StatusBadRequest, errors.New("Parâmetro limite inválido"))
+			return
+		}
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
 
+	usuarios, erro := repositories.NewUsuariosRepo(db).Autocompletar(prefixo, usuarioId, limite)
 	if erro != nil {
 		responses.Erro(w, http.StatusInternalServerError, erro)
 		return
@@ -105,7 +176,7 @@ func ListarUsuarios(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary		Buscar Usuário
-// @Description Busca um usuário por ID
+// @Description Busca um usuário por ID. Quem bloqueou o usuário autenticado não é encontrado
 // @Tags 	usuarios
 // @Accept	json
 // @Produce	json
@@ -133,8 +204,14 @@ func BuscarUsuario(w http.ResponseWriter, r *http.Request) {
 	}
 	defer db.Close()
 
+	usuarioIdToken, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
 	repositorio := repositories.NewUsuariosRepo(db)
-	usuario, erro := repositorio.BuscarPorId(ID)
+	usuario, erro := repositorio.BuscarPorId(ID, usuarioIdToken)
 	if erro != nil {
 		if erro == sql.ErrNoRows {
 			responses.Erro(w, http.StatusNotFound, erro)
@@ -145,6 +222,7 @@ func BuscarUsuario(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	usuario.OcultarPrivados(usuarioIdToken)
 	responses.JSON(w, http.StatusOK, usuario)
 }
 
@@ -230,6 +308,102 @@ func AtualizarUsuario(w http.ResponseWriter, r *http.Request) {
 	responses.JSON(w, http.StatusNoContent, nil)
 }
 
+// @Summary		Atualizar Perfil
+// @Description Atualiza parcialmente o perfil do usuário autenticado: só os campos enviados são alterados.
+// @Description O site é validado (sem esquema, assume https), a data de nascimento usa o formato AAAA-MM-DD
+// @Description e só aparece para outros usuários com dataNascimentoPublica. publicacaoFixadaId 0 desafixa
+// @Description Com privado = true, novos seguidores precisam ser aceitos; ao voltar a ser pública, as solicitações pendentes são aceitas
+// @Tags 	usuarios
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID do usuário"
+// @Param perfil body models.AtualizarPerfilRequest true "Campos a alterar"
+// @Success	200 {object} models.Usuario
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /usuarios/{id} [patch]
+func AtualizarPerfil(w http.ResponseWriter, r *http.Request) {
+	parametros := mux.Vars(r)
+
+	usuarioId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	usuarioIdToken, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	if usuarioId != usuarioIdToken {
+		responses.Erro(w, http.StatusForbidden, errors.New("Não é possível atualizar um usuário que não seja o seu"))
+		return
+	}
+
+	bodyRequest, erro := io.ReadAll(r.Body)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnprocessableEntity, erro)
+		return
+	}
+
+	var request models.AtualizarPerfilRequest
+	if erro = json.Unmarshal(bodyRequest, &request); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewUsuariosRepo(db)
+	usuario, erro := repositorio.BuscarPorId(usuarioId, usuarioId)
+	if erro != nil {
+		if erro == sql.ErrNoRows {
+			responses.Erro(w, http.StatusNotFound, erro)
+			return
+		}
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	request.Aplicar(&usuario)
+	if erro := usuario.Preparar(false); erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	if request.PublicacaoFixadaId != nil && usuario.PublicacaoFixadaId != nil {
+		podeFixar, erro := repositories.NewPublicacoesRepo(db).PublicacaoPublicadaDoUsuario(*usuario.PublicacaoFixadaId, usuarioId)
+		if erro != nil {
+			responses.Erro(w, http.StatusInternalServerError, erro)
+			return
+		}
+
+		if !podeFixar {
+			responses.Erro(w, http.StatusBadRequest, errors.New("Só é possível fixar uma publicação própria já publicada"))
+			return
+		}
+	}
+
+	if erro = repositorio.AtualizarPerfil(usuarioId, usuario); erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, usuario)
+}
+
 // @Summary		Deletar Usuário
 // @Description Deleta um usuário
 // @Tags 	usuarios
@@ -273,6 +447,12 @@ func DeletarUsuario(w http.ResponseWriter, r *http.Request) {
 	defer db.Close()
 
 	repositorio := repositories.NewUsuariosRepo(db)
+	avatar, banner, erro := repositorio.BuscarImagens(usuarioId)
+	if erro != nil && erro != sql.ErrNoRows {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
 	if erro = repositorio.Deletar(usuarioId); erro != nil {
 		if erro == sql.ErrNoRows {
 			responses.Erro(w, http.StatusNotFound, erro)
@@ -282,11 +462,21 @@ func DeletarUsuario(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if midias, erro := armazenamento.Novo(); erro == nil {
+		if avatar.Valid {
+			removerArquivos(midias, chavesVariantes(avatar.String, midia.TamanhosAvatar)...)
+		}
+		if banner.Valid {
+			removerArquivos(midias, chavesVariantes(banner.String, midia.TamanhosBanner)...)
+		}
+	}
+
 	responses.JSON(w, http.StatusNoContent, nil)
 }
 
 // @Summary		Alternar Seguir Usuário
-// @Description Segue ou deixa de seguir um usuário
+// @Description Segue ou deixa de seguir um usuário. Em contas privadas, seguir cria uma solicitação e deixar de seguir também a cancela.
+// @Description Prefira PUT e DELETE em /usuarios/{id}/seguir, que podem ser repetidos com segurança
 // @Tags 	usuarios
 // @Accept	json
 // @Produce	json
@@ -294,6 +484,8 @@ func DeletarUsuario(w http.ResponseWriter, r *http.Request) {
 // @Success	204
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
 // @Router /usuarios/{id}/seguir [post]
@@ -324,7 +516,23 @@ func AlternarSeguirUsuario(w http.ResponseWriter, r *http.Request) {
 	defer db.Close()
 
 	repositorio := repositories.NewUsuariosRepo(db)
-	if erro = repositorio.AlternarSeguir(usuarioId, seguidorId); erro != nil {
+	existe, erro := repositorio.UsuarioExiste(usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if !existe {
+		responses.Erro(w, http.StatusNotFound, errors.New("Usuário não encontrado"))
+		return
+	}
+
+	erro = repositorio.AlternarSeguir(usuarioId, seguidorId)
+	if erro == repositories.ErrBloqueado {
+		responses.Erro(w, http.StatusForbidden, erro)
+		return
+	}
+	if erro != nil {
 		responses.Erro(w, http.StatusInternalServerError, erro)
 		return
 	}
@@ -333,18 +541,126 @@ func AlternarSeguirUsuario(w http.ResponseWriter, r *http.Request) {
 
 }
 
+// @Summary		Seguir Usuário
+// @Description Segue um usuário. Se a conta for privada, cria uma solicitação que o dono precisa aceitar e o estado volta com solicitado = true.
+// @Description Repetir a chamada mantém o usuário seguido ou a solicitação pendente
+// @Tags 	usuarios
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID do usuário"
+// @Success	200 {object} models.EstadoSeguir
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /usuarios/{id}/seguir [put]
+func SeguirUsuario(w http.ResponseWriter, r *http.Request) {
+	definirSeguir(w, r, true)
+}
+
+// @Summary		Deixar de Seguir Usuário
+// @Description Deixa de seguir um usuário ou cancela a solicitação pendente. Repetir a chamada mantém o usuário não seguido
+// @Tags 	usuarios
+// @Accept	json
+// @Produce	json
+// @Param id path int true "ID do usuário"
+// @Success	200 {object} models.EstadoSeguir
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /usuarios/{id}/seguir [delete]
+func DeixarDeSeguirUsuario(w http.ResponseWriter, r *http.Request) {
+	definirSeguir(w, r, false)
+}
+
+// definirSeguir leva o vínculo do usuário autenticado com o usuário da rota
+// ao estado pedido e responde com o estado final
+func definirSeguir(w http.ResponseWriter, r *http.Request, seguir bool) {
+	seguidorId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, nil)
+		return
+	}
+
+	parametros := mux.Vars(r)
+	usuarioId, erro := strconv.ParseUint(parametros["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	if usuarioId == seguidorId {
+		responses.Erro(w, http.StatusBadRequest, errors.New("Você não pode seguir ou deixar de seguir a você mesmo"))
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewUsuariosRepo(db)
+	existe, erro := repositorio.UsuarioExiste(usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if !existe {
+		responses.Erro(w, http.StatusNotFound, errors.New("Usuário não encontrado"))
+		return
+	}
+
+	if seguir {
+		erro = repositorio.Seguir(usuarioId, seguidorId)
+	} else {
+		erro = repositorio.DeixarDeSeguir(usuarioId, seguidorId)
+	}
+	if erro == repositories.ErrBloqueado {
+		responses.Erro(w, http.StatusForbidden, erro)
+		return
+	}
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	estado, erro := repositorio.EstadoSeguir(usuarioId, seguidorId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, estado)
+}
+
 // @Summary		Buscar Seguidores
-// @Description Busca os seguidores de um usuário
+// @Description Busca os seguidores de um usuário. Em contas privadas, só o dono e seus seguidores podem ver a lista
 // @Tags 	usuarios
 // @Accept	json
 // @Produce	json
 // @Param id path int true "ID do usuário"
 // @Success	200 {array} models.Usuario
 // @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
 // @Router /usuarios/{id}/seguidores [get]
 func BuscarSeguidores(w http.ResponseWriter, r *http.Request) {
+	visitanteId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
 	parametros := mux.Vars(r)
 	usuarioId, erro := strconv.ParseUint(parametros["id"], 10, 64)
 	if erro != nil {
@@ -360,6 +676,10 @@ func BuscarSeguidores(w http.ResponseWriter, r *http.Request) {
 	defer db.Close()
 
 	repositorio := repositories.NewUsuariosRepo(db)
+	if !perfilVisivel(w, repositorio, usuarioId, visitanteId) {
+		return
+	}
+
 	usuarios, erro := repositorio.BuscarSeguidores(usuarioId)
 	if erro != nil {
 		responses.Erro(w, http.StatusInternalServerError, erro)
@@ -370,17 +690,26 @@ func BuscarSeguidores(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary		Buscar Seguindo
-// @Description Busca os usuários que um usuário está seguindo
+// @Description Busca os usuários que um usuário está seguindo. Em contas privadas, só o dono e seus seguidores podem ver a lista
 // @Tags 	usuarios
 // @Accept	json
 // @Produce	json
 // @Param id path int true "ID do usuário"
 // @Success	200 {array} models.Usuario
 // @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Security BearerAuth
 // @Router /usuarios/{id}/seguindo [get]
 func BuscarSeguindo(w http.ResponseWriter, r *http.Request) {
+	visitanteId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
 	parametros := mux.Vars(r)
 	usuarioId, erro := strconv.ParseUint(parametros["id"], 10, 64)
 	if erro != nil {
@@ -396,6 +725,10 @@ func BuscarSeguindo(w http.ResponseWriter, r *http.Request) {
 	defer db.Close()
 
 	repositorio := repositories.NewUsuariosRepo(db)
+	if !perfilVisivel(w, repositorio, usuarioId, visitanteId) {
+		return
+	}
+
 	usuarios, erro := repositorio.BuscarSeguindo(usuarioId)
 	if erro != nil {
 		responses.Erro(w, http.StatusInternalServerError, erro)
@@ -405,6 +738,122 @@ func BuscarSeguindo(w http.ResponseWriter, r *http.Request) {
 	responses.JSON(w, http.StatusOK, usuarios)
 }
 
+// @Summary		Relação com Usuário
+// @Description Informa se o usuário autenticado segue o usuário e é seguido por ele, as solicitações pendentes nos
+// @Description dois sentidos, os bloqueios nos dois sentidos, se o autenticado o silenciou e quantos seguidores em
+// @Description comum eles têm. Se o usuário silenciou o autenticado não é informado
+// @Tags 	usuarios
+// @Produce	json
+// @Param id path int true "ID do usuário"
+// @Success	200 {object} models.Relacao
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /usuarios/{id}/relacao [get]
+func BuscarRelacao(w http.ResponseWriter, r *http.Request) {
+	visitanteId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	usuarioId, erro := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	if usuarioId == visitanteId {
+		responses.Erro(w, http.StatusBadRequest, errors.New("Informe outro usuário"))
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewUsuariosRepo(db)
+	existe, erro := repositorio.UsuarioExiste(usuarioId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	if !existe {
+		responses.Erro(w, http.StatusNotFound, errors.New("Usuário não encontrado"))
+		return
+	}
+
+	relacao, erro := repositorio.Relacao(usuarioId, visitanteId)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, relacao)
+}
+
+// @Summary		Buscar Seguidores em Comum
+// @Description Lista quem o usuário autenticado segue e também segue o usuário. Em contas privadas, só o dono e
+// @Description seus seguidores podem ver a lista
+// @Tags 	usuarios
+// @Produce	json
+// @Param id path int true "ID do usuário"
+// @Param pagina query int false "Página (começa em 1)"
+// @Param limite query int false "Quantidade de itens por página (máximo 100)"
+// @Success	200 {array} models.Usuario
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /usuarios/{id}/seguidores-em-comum [get]
+func BuscarSeguidoresEmComum(w http.ResponseWriter, r *http.Request) {
+	visitanteId, erro := authentication.ExtrairUsuarioId(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusUnauthorized, erro)
+		return
+	}
+
+	usuarioId, erro := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	paginacao, erro := extrairPaginacao(r)
+	if erro != nil {
+		responses.Erro(w, http.StatusBadRequest, erro)
+		return
+	}
+
+	db, erro := database.Conectar()
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewUsuariosRepo(db)
+	if !perfilVisivel(w, repositorio, usuarioId, visitanteId) {
+		return
+	}
+
+	usuarios, erro := repositorio.BuscarSeguidoresEmComum(usuarioId, visitanteId, paginacao)
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, usuarios)
+}
+
 // @Summary		Alterar Senha
 // @Description Altera a senha do usuário autenticado
 // @Tags 	usuarios
@@ -476,3 +925,23 @@ func AlterarSenha(w http.ResponseWriter, r *http.Request) {
 	responses.JSON(w, http.StatusNoContent, nil)
 
 }
+
+// perfilVisivel responde 404 quando o usuário não existe e 403 quando o visitante não pode ver o conteúdo de uma conta privada
+func perfilVisivel(w http.ResponseWriter, repositorio *repositories.Usuarios, usuarioId uint64, visitanteId uint64) bool {
+	visivel, erro := repositorio.PerfilVisivel(usuarioId, visitanteId)
+	if errors.Is(erro, sql.ErrNoRows) {
+		responses.Erro(w, http.StatusNotFound, errors.New("Usuário não encontrado"))
+		return false
+	}
+	if erro != nil {
+		responses.Erro(w, http.StatusInternalServerError, erro)
+		return false
+	}
+
+	if !visivel {
+		responses.Erro(w, http.StatusForbidden, errors.New("Esta conta é privada"))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,60 @@
+// Package diff compara textos palavra a palavra
+package diff
+
+import "strings"
+
+// Palavras retorna o texto novo marcando o que saiu do antigo com [-...-] e o
+// que entrou com {+...+}, como o git diff --word-diff. Espaços são normalizados
+func Palavras(antigo, novo string) string {
+	a := strings.Fields(antigo)
+	b := strings.Fields(novo)
+
+	// lcs[i][j] é o tamanho da maior subsequência comum entre a[i:] e b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var partes, removidas, inseridas []string
+	descarregar := func() {
+		if len(removidas) > 0 {
+			partes = append(partes, "[-"+strings.Join(removidas, " ")+"-]")
+			removidas = nil
+		}
+		if len(inseridas) > 0 {
+			partes = append(partes, "{+"+strings.Join(inseridas, " ")+"+}")
+			inseridas = nil
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			descarregar()
+			partes = append(partes, a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			removidas = append(removidas, a[i])
+			i++
+		default:
+			inseridas = append(inseridas, b[j])
+			j++
+		}
+	}
+	removidas = append(removidas, a[i:]...)
+	inseridas = append(inseridas, b[j:]...)
+	descarregar()
+
+	return strings.Join(partes, " ")
+}
@@ -0,0 +1,28 @@
+package diff
+
+import "testing"
+
+func TestPalavras(t *testing.T) {
+	casos := []struct {
+		nome, antigo, novo, want string
+	}{
+		{"iguais", "o gato preto", "o gato preto", "o gato preto"},
+		{"só espaços", "o  gato\npreto", "o gato preto", "o gato preto"},
+		{"troca", "o gato preto", "o cão preto", "o [-gato-] {+cão+} preto"},
+		{"inclusão no fim", "o gato", "o gato preto", "o gato {+preto+}"},
+		{"remoção no começo", "então o gato", "o gato", "[-então-] o gato"},
+		{"tudo diferente", "a b", "c d", "[-a b-] {+c d+}"},
+		{"antigo vazio", "", "a b", "{+a b+}"},
+		{"novo vazio", "a b", "", "[-a b-]"},
+		{"ambos vazios", "", "", ""},
+		{"várias mudanças", "a b c d", "a x c y", "a [-b-] {+x+} c [-d-] {+y+}"},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			if got := Palavras(caso.antigo, caso.novo); got != caso.want {
+				t.Errorf("Palavras(%q, %q) = %q, want %q", caso.antigo, caso.novo, got, caso.want)
+			}
+		})
+	}
+}
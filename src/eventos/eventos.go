@@ -0,0 +1,42 @@
+package eventos
+
+import (
+	"api/src/models"
+	"context"
+)
+
+var (
+	hub   *Hub
+	fonte Fonte
+)
+
+// Iniciar passa a entregar aos clientes desta instância os eventos da fonte, com filas de
+// tamanhoFila eventos por conexão. Não bloqueia: a fonte é acompanhada até o contexto ser cancelado
+func Iniciar(ctx context.Context, fonteEventos Fonte, tamanhoFila int) {
+	hub = NovoHub(tamanhoFila)
+	fonte = fonteEventos
+	go fonte.Acompanhar(ctx, hub.Entregar)
+}
+
+// Assinar abre uma assinatura no hub desta instância
+func Assinar(usuarioId uint64, seguindo map[uint64]bool) *Assinatura {
+	return hub.Assinar(usuarioId, seguindo)
+}
+
+// Atualizar troca os autores que a assinatura acompanha
+func Atualizar(assinatura *Assinatura, seguindo map[uint64]bool) {
+	hub.Atualizar(assinatura, seguindo)
+}
+
+// Cancelar encerra a assinatura
+func Cancelar(assinatura *Assinatura) {
+	hub.Cancelar(assinatura)
+}
+
+// Desde busca na fonte os eventos posteriores a ultimoId. Sem fonte, não há como retomar
+func Desde(ultimoId uint64, limite int) ([]models.Evento, bool, error) {
+	if fonte == nil {
+		return nil, false, nil
+	}
+	return fonte.Desde(ultimoId, limite)
+}
@@ -0,0 +1,174 @@
+package eventos
+
+import (
+	"api/src/database"
+	"api/src/models"
+	"api/src/repositories"
+	"context"
+	"log"
+	"time"
+)
+
+// Fonte é de onde vêm os eventos entregues pelo hub. Com várias instâncias da API, a fonte
+// precisa ser compartilhada entre elas para que cada uma receba os eventos gerados pelas outras
+type Fonte interface {
+	// Acompanhar chama entregar para cada evento novo até o contexto ser cancelado
+	Acompanhar(ctx context.Context, entregar func(models.Evento))
+	// Desde retorna até limite eventos posteriores a ultimoId, para o cliente que reconecta.
+	// completo é false quando não dá para retomar, porque eventos já foram descartados
+	// ou porque há mais do que limite
+	Desde(ultimoId uint64, limite int) (eventos []models.Evento, completo bool, erro error)
+}
+
+const (
+	// loteEventos é quantos eventos a fonte do banco lê por consulta
+	loteEventos = 500
+	// esperaLacuna é por quanto tempo um id pulado continua sendo procurado. Ids são reservados na
+	// inserção, então a transação de um id menor pode ser confirmada depois da de um maior
+	esperaLacuna = 10 * time.Second
+	// intervaloLimpeza é de quanto em quanto tempo os eventos mais antigos que a retenção são apagados
+	intervaloLimpeza = time.Minute
+)
+
+// FonteBanco lê os eventos da tabela eventos, em que os repositórios gravam na mesma transação
+// das mudanças. Todas as instâncias leem a mesma tabela
+type FonteBanco struct {
+	Intervalo time.Duration
+	Retencao  time.Duration
+}
+
+// leituraBanco é o progresso da fonte do banco entre uma rodada e outra
+type leituraBanco struct {
+	iniciada bool
+	ultimoId uint64
+	lacunas  map[uint64]time.Time
+	limpaEm  time.Time
+}
+
+// Acompanhar procura eventos novos a cada intervalo. Começa do evento mais recente no momento
+// em que é chamado: o que veio antes é recuperado pelos clientes com Desde
+func (fonte FonteBanco) Acompanhar(ctx context.Context, entregar func(models.Evento)) {
+	ticker := time.NewTicker(fonte.Intervalo)
+	defer ticker.Stop()
+
+	leitura := leituraBanco{lacunas: make(map[uint64]time.Time)}
+	for {
+		if erro := fonte.ler(&leitura, entregar); erro != nil {
+			log.Printf("eventos: erro ao ler eventos: %v", erro)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (fonte FonteBanco) ler(leitura *leituraBanco, entregar func(models.Evento)) error {
+	db, erro := database.Conectar()
+	if erro != nil {
+		return erro
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewEventosRepo(db)
+	if !leitura.iniciada {
+		if leitura.ultimoId, erro = repositorio.UltimoId(); erro != nil {
+			return erro
+		}
+		leitura.iniciada = true
+		leitura.limpaEm = time.Now()
+		return nil
+	}
+
+	agora := time.Now()
+	if len(leitura.lacunas) > 0 {
+		ids := make([]uint64, 0, len(leitura.lacunas))
+		for id := range leitura.lacunas {
+			ids = append(ids, id)
+		}
+
+		eventos, lidos, erro := repositorio.BuscarIds(ids)
+		if erro != nil {
+			return erro
+		}
+		for _, id := range lidos {
+			delete(leitura.lacunas, id)
+		}
+		for _, evento := range eventos {
+			entregar(evento)
+		}
+
+		for id, desde := range leitura.lacunas {
+			if agora.Sub(desde) > esperaLacuna {
+				delete(leitura.lacunas, id)
+			}
+		}
+	}
+
+	for {
+		eventos, lidos, erro := repositorio.BuscarDesde(leitura.ultimoId, loteEventos)
+		if erro != nil {
+			return erro
+		}
+		if len(lidos) == 0 {
+			break
+		}
+
+		proximo := leitura.ultimoId + 1
+		for _, id := range lidos {
+			for ; proximo < id; proximo++ {
+				leitura.lacunas[proximo] = agora
+			}
+			proximo = id + 1
+		}
+		leitura.ultimoId = lidos[len(lidos)-1]
+
+		for _, evento := range eventos {
+			entregar(evento)
+		}
+
+		if len(lidos) < loteEventos {
+			break
+		}
+	}
+
+	if agora.Sub(leitura.limpaEm) >= intervaloLimpeza {
+		if erro := repositorio.RemoverAntigos(agora.Add(-fonte.Retencao)); erro != nil {
+			return erro
+		}
+		leitura.limpaEm = agora
+	}
+
+	return nil
+}
+
+// Desde lê os eventos direto do banco. Como o evento mais recente nunca é apagado, um primeiro
+// id maior que ultimoId + 1 indica que o cliente perdeu eventos
+func (fonte FonteBanco) Desde(ultimoId uint64, limite int) ([]models.Evento, bool, error) {
+	db, erro := database.Conectar()
+	if erro != nil {
+		return nil, false, erro
+	}
+	defer db.Close()
+
+	repositorio := repositories.NewEventosRepo(db)
+	primeiroId, erro := repositorio.PrimeiroId()
+	if erro != nil {
+		return nil, false, erro
+	}
+	if primeiroId == 0 || ultimoId+1 < primeiroId {
+		return nil, false, nil
+	}
+
+	eventos, lidos, erro := repositorio.BuscarDesde(ultimoId, limite)
+	if erro != nil {
+		return nil, false, erro
+	}
+	if len(lidos) >= limite {
+		return nil, false, nil
+	}
+
+	return eventos, true, nil
+}
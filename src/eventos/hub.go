@@ -0,0 +1,91 @@
+// Package eventos entrega em tempo real, aos clientes conectados nesta instância, os eventos
+// gravados pelos repositórios
+package eventos
+
+import (
+	"api/src/models"
+	"sync"
+)
+
+// Hub distribui os eventos entre as assinaturas abertas nesta instância
+type Hub struct {
+	mutex       sync.Mutex
+	assinaturas map[*Assinatura]struct{}
+	tamanhoFila int
+}
+
+// Assinatura é a conexão de um usuário com o hub. Cada uma tem a sua fila; quando o cliente não
+// consome rápido o bastante e a fila enche, ela é encerrada e o canal fechado, para que o cliente
+// reconecte e retome a partir do último evento recebido
+type Assinatura struct {
+	usuarioId uint64
+	seguindo  map[uint64]bool
+	eventos   chan models.Evento
+}
+
+// NovoHub cria um hub em que cada assinatura acumula até tamanhoFila eventos
+func NovoHub(tamanhoFila int) *Hub {
+	return &Hub{assinaturas: make(map[*Assinatura]struct{}), tamanhoFila: tamanhoFila}
+}
+
+// Eventos é o canal com os eventos destinados ao usuário. É fechado quando a assinatura é encerrada
+func (assinatura *Assinatura) Eventos() <-chan models.Evento {
+	return assinatura.eventos
+}
+
+// Assinar abre uma assinatura para o usuário, que recebe os eventos dele e dos autores em seguindo
+func (hub *Hub) Assinar(usuarioId uint64, seguindo map[uint64]bool) *Assinatura {
+	assinatura := &Assinatura{
+		usuarioId: usuarioId,
+		seguindo:  seguindo,
+		eventos:   make(chan models.Evento, hub.tamanhoFila),
+	}
+
+	hub.mutex.Lock()
+	hub.assinaturas[assinatura] = struct{}{}
+	hub.mutex.Unlock()
+
+	return assinatura
+}
+
+// Atualizar troca os autores que a assinatura acompanha, depois de uma mudança de relação do usuário
+func (hub *Hub) Atualizar(assinatura *Assinatura, seguindo map[uint64]bool) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	assinatura.seguindo = seguindo
+}
+
+// Cancelar encerra a assinatura. Pode ser chamado mais de uma vez
+func (hub *Hub) Cancelar(assinatura *Assinatura) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	hub.encerrar(assinatura)
+}
+
+// Entregar coloca o evento na fila de cada assinatura a que ele se destina, sem nunca esperar
+// por um cliente lento
+func (hub *Hub) Entregar(evento models.Evento) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	for assinatura := range hub.assinaturas {
+		if !evento.Destinado(assinatura.usuarioId, assinatura.seguindo) {
+			continue
+		}
+
+		select {
+		case assinatura.eventos <- evento:
+		default:
+			hub.encerrar(assinatura)
+		}
+	}
+}
+
+func (hub *Hub) encerrar(assinatura *Assinatura) {
+	if _, aberta := hub.assinaturas[assinatura]; aberta {
+		delete(hub.assinaturas, assinatura)
+		close(assinatura.eventos)
+	}
+}
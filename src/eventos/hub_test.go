@@ -0,0 +1,101 @@
+package eventos
+
+import (
+	"api/src/models"
+	"testing"
+)
+
+func eventoPara(id uint64, usuarioId uint64) models.Evento {
+	return models.Evento{Id: id, Tipo: models.EventoNotificacao, UsuarioId: &usuarioId}
+}
+
+func eventoDe(id uint64, autorId uint64) models.Evento {
+	return models.Evento{Id: id, Tipo: models.EventoPublicacao, AutorId: &autorId}
+}
+
+// receber lê o que já está na fila, sem esperar, e informa se o canal foi fechado
+func receber(assinatura *Assinatura) (ids []uint64, fechada bool) {
+	for {
+		select {
+		case evento, aberta := <-assinatura.Eventos():
+			if !aberta {
+				return ids, true
+			}
+			ids = append(ids, evento.Id)
+		default:
+			return ids, false
+		}
+	}
+}
+
+func TestEntregarFilaCheiaEncerraAssinatura(t *testing.T) {
+	hub := NovoHub(2)
+	lenta := hub.Assinar(1, nil)
+	outra := hub.Assinar(2, nil)
+
+	for id := uint64(1); id <= 3; id++ {
+		hub.Entregar(eventoPara(id, 1))
+	}
+	hub.Entregar(eventoPara(4, 2))
+
+	ids, fechada := receber(lenta)
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("fila cheia entregou %v, want [1 2]", ids)
+	}
+	if !fechada {
+		t.Error("assinatura com a fila cheia não foi encerrada")
+	}
+
+	ids, fechada = receber(outra)
+	if len(ids) != 1 || ids[0] != 4 || fechada {
+		t.Errorf("outra assinatura recebeu %v (fechada %v), want [4] aberta", ids, fechada)
+	}
+
+	// Depois de encerrada, a assinatura não recebe nem fecha de novo
+	hub.Entregar(eventoPara(5, 1))
+	hub.Cancelar(lenta)
+	if _, ok := hub.assinaturas[lenta]; ok {
+		t.Error("assinatura encerrada continua no hub")
+	}
+}
+
+func TestEntregarIgnoraEventosDeOutros(t *testing.T) {
+	hub := NovoHub(1)
+	assinatura := hub.Assinar(1, map[uint64]bool{2: true})
+
+	// Eventos que não são para o usuário não ocupam a fila
+	hub.Entregar(eventoPara(1, 3))
+	hub.Entregar(eventoDe(2, 4))
+	hub.Entregar(eventoDe(3, 2))
+
+	ids, fechada := receber(assinatura)
+	if len(ids) != 1 || ids[0] != 3 || fechada {
+		t.Errorf("recebeu %v (fechada %v), want [3] aberta", ids, fechada)
+	}
+}
+
+func TestAtualizarTrocaAutoresAcompanhados(t *testing.T) {
+	hub := NovoHub(4)
+	assinatura := hub.Assinar(1, map[uint64]bool{2: true})
+
+	hub.Atualizar(assinatura, map[uint64]bool{3: true})
+	hub.Entregar(eventoDe(1, 2))
+	hub.Entregar(eventoDe(2, 3))
+
+	ids, _ := receber(assinatura)
+	if len(ids) != 1 || ids[0] != 2 {
+		t.Errorf("recebeu %v, want [2]", ids)
+	}
+}
+
+func TestCancelarMaisDeUmaVez(t *testing.T) {
+	hub := NovoHub(1)
+	assinatura := hub.Assinar(1, nil)
+
+	hub.Cancelar(assinatura)
+	hub.Cancelar(assinatura)
+
+	if _, fechada := receber(assinatura); !fechada {
+		t.Error("canal da assinatura cancelada continua aberto")
+	}
+}
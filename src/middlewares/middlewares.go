@@ -9,7 +9,13 @@ import (
 
 func Logger(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("\n %s %s %s", r.Method, r.RequestURI, r.Host)
+		uri := r.RequestURI
+		if consulta := r.URL.Query(); consulta.Has("token") {
+			// o token pode vir na URL do stream de eventos e não deve parar no log
+			consulta.Set("token", "***")
+			uri = r.URL.Path + "?" + consulta.Encode()
+		}
+		log.Printf("\n %s %s %s", r.Method, uri, r.Host)
 		next(w, r)
 	}
 }
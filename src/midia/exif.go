@@ -0,0 +1,110 @@
+package midia
+
+import (
+	"encoding/binary"
+	"image"
+)
+
+// orientacaoExif lê a tag Orientation (0x0112) do EXIF de um JPEG.
+// Retorna 1 (sem rotação) quando não há EXIF ou ele não pode ser lido
+func orientacaoExif(dados []byte) int {
+	if len(dados) < 4 || dados[0] != 0xFF || dados[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(dados); {
+		if dados[i] != 0xFF {
+			return 1
+		}
+		marcador := dados[i+1]
+		tamanho := int(binary.BigEndian.Uint16(dados[i+2:]))
+		if marcador == 0xDA || tamanho < 2 || i+2+tamanho > len(dados) {
+			return 1
+		}
+
+		segmento := dados[i+4 : i+2+tamanho]
+		if marcador == 0xE1 && len(segmento) > 6 && string(segmento[:6]) == "Exif\x00\x00" {
+			return orientacaoTiff(segmento[6:])
+		}
+		i += 2 + tamanho
+	}
+	return 1
+}
+
+func orientacaoTiff(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var ordem binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		ordem = binary.LittleEndian
+	case "MM":
+		ordem = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(ordem.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entradas := int(ordem.Uint16(tiff[ifd:]))
+	for i := 0; i < entradas; i++ {
+		entrada := ifd + 2 + i*12
+		if entrada+12 > len(tiff) {
+			return 1
+		}
+		if ordem.Uint16(tiff[entrada:]) == 0x0112 {
+			orientacao := int(ordem.Uint16(tiff[entrada+8:]))
+			if orientacao < 1 || orientacao > 8 {
+				return 1
+			}
+			return orientacao
+		}
+	}
+	return 1
+}
+
+// aplicarOrientacao gira e espelha a imagem para que ela fique de pé sem depender do EXIF
+func aplicarOrientacao(imagem image.Image, orientacao int) image.Image {
+	if orientacao <= 1 {
+		return imagem
+	}
+
+	limites := imagem.Bounds()
+	largura, altura := limites.Dx(), limites.Dy()
+
+	// As orientações 5 a 8 trocam largura e altura
+	destinoLargura, destinoAltura := largura, altura
+	if orientacao >= 5 {
+		destinoLargura, destinoAltura = altura, largura
+	}
+	destino := image.NewRGBA(image.Rect(0, 0, destinoLargura, destinoAltura))
+
+	for y := 0; y < altura; y++ {
+		for x := 0; x < largura; x++ {
+			var dx, dy int
+			switch orientacao {
+			case 2:
+				dx, dy = largura-1-x, y
+			case 3:
+				dx, dy = largura-1-x, altura-1-y
+			case 4:
+				dx, dy = x, altura-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = altura-1-y, x
+			case 7:
+				dx, dy = altura-1-y, largura-1-x
+			case 8:
+				dx, dy = y, largura-1-x
+			}
+			destino.Set(dx, dy, imagem.At(limites.Min.X+x, limites.Min.Y+y))
+		}
+	}
+	return destino
+}
@@ -0,0 +1,126 @@
+// Package midia valida e prepara as imagens enviadas pelos usuários
+// e gera as URLs assinadas usadas para servi-las
+package midia
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"golang.org/x/image/draw"
+)
+
+// pixelsMaximos protege contra imagens pequenas em bytes que ocupam muita memória ao decodificar
+const pixelsMaximos = 40_000_000
+
+var (
+	ErrTipoNaoSuportado = errors.New("Tipo de arquivo não suportado. Envie JPEG, PNG ou GIF")
+	ErrImagemGrande     = errors.New("Imagem com dimensões acima do permitido")
+)
+
+// Imagem é o resultado do processamento de um envio, pronto para ser armazenado
+type Imagem struct {
+	Conteudo  []byte
+	Miniatura []byte
+	Tipo      string
+	Extensao  string
+	Largura   int
+	Altura    int
+}
+
+// Processar confere o tipo real do arquivo pelo conteúdo, corrige a orientação das fotos,
+// descarta metadados como EXIF (localização, câmera) ao recodificar e gera a miniatura,
+// que cabe em um quadrado de ladoMiniatura pixels
+func Processar(dados []byte, ladoMiniatura int) (Imagem, error) {
+	original, imagem, erro := decodificar(dados)
+	if erro != nil {
+		return Imagem{}, erro
+	}
+
+	limites := original.Bounds()
+	imagem.Largura = limites.Dx()
+	imagem.Altura = limites.Dy()
+
+	if imagem.Conteudo, erro = codificar(original, imagem.Tipo); erro != nil {
+		return Imagem{}, erro
+	}
+	if imagem.Miniatura, erro = codificar(redimensionar(original, ladoMiniatura), imagem.Tipo); erro != nil {
+		return Imagem{}, erro
+	}
+
+	return imagem, nil
+}
+
+// decodificar confere o tipo pelo conteúdo, decodifica com a orientação corrigida e
+// devolve a Imagem só com Tipo e Extensao de saída preenchidos. GIF vira PNG: só o
+// primeiro quadro é mantido e PNG preserva a transparência
+func decodificar(dados []byte) (image.Image, Imagem, error) {
+	tipo := http.DetectContentType(dados)
+
+	var decodificador func(io.Reader) (image.Image, error)
+	switch tipo {
+	case "image/jpeg":
+		decodificador = jpeg.Decode
+	case "image/png":
+		decodificador = png.Decode
+	case "image/gif":
+		decodificador = gif.Decode
+	default:
+		return nil, Imagem{}, ErrTipoNaoSuportado
+	}
+
+	configuracao, _, erro := image.DecodeConfig(bytes.NewReader(dados))
+	if erro != nil {
+		return nil, Imagem{}, ErrTipoNaoSuportado
+	}
+	if configuracao.Width*configuracao.Height > pixelsMaximos {
+		return nil, Imagem{}, ErrImagemGrande
+	}
+
+	original, erro := decodificador(bytes.NewReader(dados))
+	if erro != nil {
+		return nil, Imagem{}, ErrTipoNaoSuportado
+	}
+
+	if tipo == "image/jpeg" {
+		return aplicarOrientacao(original, orientacaoExif(dados)), Imagem{Tipo: "image/jpeg", Extensao: "jpg"}, nil
+	}
+	return original, Imagem{Tipo: "image/png", Extensao: "png"}, nil
+}
+
+func codificar(imagem image.Image, tipo string) ([]byte, error) {
+	var buffer bytes.Buffer
+	var erro error
+	if tipo == "image/jpeg" {
+		erro = jpeg.Encode(&buffer, imagem, &jpeg.Options{Quality: 90})
+	} else {
+		erro = png.Encode(&buffer, imagem)
+	}
+	return buffer.Bytes(), erro
+}
+
+// redimensionar mantém a proporção para caber no quadrado, sem ampliar
+func redimensionar(imagem image.Image, lado int) image.Image {
+	limites := imagem.Bounds()
+	largura, altura := limites.Dx(), limites.Dy()
+	if largura <= lado && altura <= lado {
+		return imagem
+	}
+
+	if largura >= altura {
+		altura = max(1, altura*lado/largura)
+		largura = lado
+	} else {
+		largura = max(1, largura*lado/altura)
+		altura = lado
+	}
+
+	destino := image.NewRGBA(image.Rect(0, 0, largura, altura))
+	draw.CatmullRom.Scale(destino, destino.Bounds(), imagem, limites, draw.Over, nil)
+	return destino
+}
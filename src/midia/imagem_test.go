@@ -0,0 +1,205 @@
+package midia
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// imagemTeste tem o canto superior esquerdo vermelho e o resto azul, para conferir rotações
+func imagemTeste(largura, altura int) *image.RGBA {
+	imagem := image.NewRGBA(image.Rect(0, 0, largura, altura))
+	for y := 0; y < altura; y++ {
+		for x := 0; x < largura; x++ {
+			cor := color.RGBA{B: 255, A: 255}
+			if x < largura/4 && y < altura/4 {
+				cor = color.RGBA{R: 255, A: 255}
+			}
+			imagem.Set(x, y, cor)
+		}
+	}
+	return imagem
+}
+
+func codificarPNG(t *testing.T, imagem image.Image) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	if erro := png.Encode(&buffer, imagem); erro != nil {
+		t.Fatal(erro)
+	}
+	return buffer.Bytes()
+}
+
+// jpegComOrientacao codifica a imagem e insere logo após o SOI um APP1 com a tag Orientation
+func jpegComOrientacao(t *testing.T, imagem image.Image, ordem binary.ByteOrder, orientacao uint16) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	if erro := jpeg.Encode(&buffer, imagem, &jpeg.Options{Quality: 95}); erro != nil {
+		t.Fatal(erro)
+	}
+	dados := buffer.Bytes()
+
+	tiff := make([]byte, 26)
+	if ordem == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	ordem.PutUint16(tiff[2:], 42)
+	ordem.PutUint32(tiff[4:], 8)
+	ordem.PutUint16(tiff[8:], 1)
+	ordem.PutUint16(tiff[10:], 0x0112)
+	ordem.PutUint16(tiff[12:], 3)
+	ordem.PutUint32(tiff[14:], 1)
+	ordem.PutUint16(tiff[18:], orientacao)
+
+	segmento := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segmento)+2))
+	app1 = append(app1, segmento...)
+
+	return append(append([]byte{0xFF, 0xD8}, app1...), dados[2:]...)
+}
+
+func dimensoes(t *testing.T, dados []byte) (int, int) {
+	t.Helper()
+	configuracao, _, erro := image.DecodeConfig(bytes.NewReader(dados))
+	if erro != nil {
+		t.Fatal(erro)
+	}
+	return configuracao.Width, configuracao.Height
+}
+
+func TestOrientacaoExif(t *testing.T) {
+	original := imagemTeste(8, 4)
+	casos := []struct {
+		nome  string
+		dados []byte
+		want  int
+	}{
+		{"little endian", jpegComOrientacao(t, original, binary.LittleEndian, 6), 6},
+		{"big endian", jpegComOrientacao(t, original, binary.BigEndian, 3), 3},
+		{"fora do intervalo", jpegComOrientacao(t, original, binary.LittleEndian, 9), 1},
+		{"sem EXIF", codificarPNG(t, original), 1},
+		{"truncado", jpegComOrientacao(t, original, binary.LittleEndian, 6)[:20], 1},
+		{"vazio", nil, 1},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			if got := orientacaoExif(caso.dados); got != caso.want {
+				t.Errorf("orientacaoExif = %d, want %d", got, caso.want)
+			}
+		})
+	}
+}
+
+func TestProcessarAplicaOrientacaoEDescartaExif(t *testing.T) {
+	dados := jpegComOrientacao(t, imagemTeste(80, 40), binary.LittleEndian, 6)
+
+	imagem, erro := Processar(dados, 20)
+	if erro != nil {
+		t.Fatal(erro)
+	}
+
+	// Orientação 6 gira 90° no sentido horário: a imagem fica em pé e o canto vermelho vai para a direita
+	if imagem.Largura != 40 || imagem.Altura != 80 || imagem.Tipo != "image/jpeg" || imagem.Extensao != "jpg" {
+		t.Errorf("Processar = %dx%d %s %s, want 40x80 image/jpeg jpg", imagem.Largura, imagem.Altura, imagem.Tipo, imagem.Extensao)
+	}
+	if bytes.Contains(imagem.Conteudo, []byte("Exif")) || orientacaoExif(imagem.Conteudo) != 1 {
+		t.Error("o EXIF continua no conteúdo processado")
+	}
+
+	decodificada, erro := jpeg.Decode(bytes.NewReader(imagem.Conteudo))
+	if erro != nil {
+		t.Fatal(erro)
+	}
+	if r, _, b, _ := decodificada.At(37, 2).RGBA(); r < b {
+		t.Error("o canto superior direito deveria ser vermelho depois da rotação")
+	}
+
+	if largura, altura := dimensoes(t, imagem.Miniatura); largura != 10 || altura != 20 {
+		t.Errorf("miniatura %dx%d, want 10x20", largura, altura)
+	}
+}
+
+func TestProcessarMiniatura(t *testing.T) {
+	casos := []struct {
+		nome                    string
+		largura, altura, lado   int
+		larguraMini, alturaMini int
+	}{
+		{"paisagem", 400, 200, 100, 100, 50},
+		{"retrato", 200, 400, 100, 50, 100},
+		{"menor que o lado não amplia", 60, 30, 100, 60, 30},
+		{"faixa fina não zera", 1000, 2, 100, 100, 1},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			imagem, erro := Processar(codificarPNG(t, imagemTeste(caso.largura, caso.altura)), caso.lado)
+			if erro != nil {
+				t.Fatal(erro)
+			}
+			if largura, altura := dimensoes(t, imagem.Miniatura); largura != caso.larguraMini || altura != caso.alturaMini {
+				t.Errorf("miniatura %dx%d, want %dx%d", largura, altura, caso.larguraMini, caso.alturaMini)
+			}
+		})
+	}
+}
+
+func TestProcessarGIFViraPNG(t *testing.T) {
+	paleta := image.NewPaletted(image.Rect(0, 0, 10, 10), color.Palette{color.Black, color.White})
+	var buffer bytes.Buffer
+	if erro := gif.Encode(&buffer, paleta, nil); erro != nil {
+		t.Fatal(erro)
+	}
+
+	imagem, erro := Processar(buffer.Bytes(), 5)
+	if erro != nil {
+		t.Fatal(erro)
+	}
+	if imagem.Tipo != "image/png" || imagem.Extensao != "png" {
+		t.Errorf("GIF processado como %s %s, want image/png png", imagem.Tipo, imagem.Extensao)
+	}
+	if _, erro := png.Decode(bytes.NewReader(imagem.Conteudo)); erro != nil {
+		t.Errorf("conteúdo não é PNG: %v", erro)
+	}
+}
+
+func TestProcessarRecusa(t *testing.T) {
+	casos := []struct {
+		nome  string
+		dados []byte
+		want  error
+	}{
+		{"texto", []byte("não sou uma imagem"), ErrTipoNaoSuportado},
+		{"PNG corrompido", codificarPNG(t, imagemTeste(4, 4))[:30], ErrTipoNaoSuportado},
+		{"dimensões grandes demais", pngSoCabecalho(10_000, 10_000), ErrImagemGrande},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			if _, erro := Processar(caso.dados, 100); !errors.Is(erro, caso.want) {
+				t.Errorf("Processar erro = %v, want %v", erro, caso.want)
+			}
+		})
+	}
+}
+
+// pngSoCabecalho tem só a assinatura e o IHDR: basta para ler as dimensões, não para decodificar
+func pngSoCabecalho(largura, altura uint32) []byte {
+	ihdr := []byte("IHDR\x00\x00\x00\x00\x00\x00\x00\x00\x08\x06\x00\x00\x00")
+	binary.BigEndian.PutUint32(ihdr[4:], largura)
+	binary.BigEndian.PutUint32(ihdr[8:], altura)
+
+	dados := append([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0d"), ihdr...)
+	return binary.BigEndian.AppendUint32(dados, crc32.ChecksumIEEE(ihdr))
+}
@@ -0,0 +1,81 @@
+package midia
+
+import (
+	"image"
+	"path"
+	"strings"
+
+	"golang.org/x/image/draw"
+)
+
+// Tamanho é uma das dimensões padrão em que as imagens de perfil são geradas
+type Tamanho struct {
+	Nome    string
+	Largura int
+	Altura  int
+}
+
+var (
+	TamanhosAvatar = []Tamanho{{"pequeno", 48, 48}, {"medio", 128, 128}, {"grande", 400, 400}}
+	TamanhosBanner = []Tamanho{{"pequeno", 600, 200}, {"grande", 1500, 500}}
+)
+
+// VariantesPerfil é uma imagem de perfil já gerada em cada um dos tamanhos, indexada pelo nome
+type VariantesPerfil struct {
+	Variantes map[string][]byte
+	Tipo      string
+	Extensao  string
+}
+
+// ProcessarPerfil recorta a imagem no centro para a proporção de cada tamanho e a reduz
+// para as dimensões exatas dele. Como em Processar, o tipo é conferido pelo conteúdo e
+// os metadados são descartados
+func ProcessarPerfil(dados []byte, tamanhos []Tamanho) (VariantesPerfil, error) {
+	original, imagem, erro := decodificar(dados)
+	if erro != nil {
+		return VariantesPerfil{}, erro
+	}
+
+	perfil := VariantesPerfil{
+		Variantes: make(map[string][]byte, len(tamanhos)),
+		Tipo:      imagem.Tipo,
+		Extensao:  imagem.Extensao,
+	}
+	for _, tamanho := range tamanhos {
+		conteudo, erro := codificar(preencher(original, tamanho.Largura, tamanho.Altura), imagem.Tipo)
+		if erro != nil {
+			return VariantesPerfil{}, erro
+		}
+		perfil.Variantes[tamanho.Nome] = conteudo
+	}
+
+	return perfil, nil
+}
+
+// ChaveVariante monta a chave de um tamanho a partir da chave guardada no banco:
+// "avatares/1/abc.jpg" com "pequeno" vira "avatares/1/abc_pequeno.jpg"
+func ChaveVariante(chave string, nome string) string {
+	extensao := path.Ext(chave)
+	return strings.TrimSuffix(chave, extensao) + "_" + nome + extensao
+}
+
+// preencher recorta o centro da imagem na proporção pedida e a escala para o tamanho exato
+func preencher(imagem image.Image, largura, altura int) image.Image {
+	limites := imagem.Bounds()
+	recorte := limites
+	if limites.Dx()*altura > limites.Dy()*largura {
+		// Mais larga que o destino: corta as laterais
+		novaLargura := limites.Dy() * largura / altura
+		recorte.Min.X += (limites.Dx() - novaLargura) / 2
+		recorte.Max.X = recorte.Min.X + novaLargura
+	} else {
+		// Mais alta que o destino: corta em cima e embaixo
+		novaAltura := limites.Dx() * altura / largura
+		recorte.Min.Y += (limites.Dy() - novaAltura) / 2
+		recorte.Max.Y = recorte.Min.Y + novaAltura
+	}
+
+	destino := image.NewRGBA(image.Rect(0, 0, largura, altura))
+	draw.CatmullRom.Scale(destino, destino.Bounds(), imagem, recorte, draw.Over, nil)
+	return destino
+}
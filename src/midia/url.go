@@ -0,0 +1,45 @@
+package midia
+
+import (
+	"api/src/config"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var ErrAssinaturaInvalida = errors.New("Link de mídia inválido ou expirado")
+
+// URLAssinada retorna o caminho para baixar o arquivo da chave, válido por pelo menos
+// config.MidiaValidadeURL. A expiração é arredondada para uma janela fixa, então a
+// mesma chave gera a mesma URL por um tempo e o navegador consegue usar o cache
+func URLAssinada(chave string) string {
+	validade := int64(config.MidiaValidadeURL / time.Second)
+	expira := (time.Now().Unix()/validade + 2) * validade
+
+	return fmt.Sprintf("/midias/%s?expira=%d&assinatura=%s",
+		chave, expira, url.QueryEscape(assinar(chave, expira)))
+}
+
+// VerificarAssinatura confere se a URL foi gerada por URLAssinada e ainda não expirou
+func VerificarAssinatura(chave string, expira string, assinatura string) error {
+	momento, erro := strconv.ParseInt(expira, 10, 64)
+	if erro != nil || time.Now().Unix() > momento {
+		return ErrAssinaturaInvalida
+	}
+
+	if !hmac.Equal([]byte(assinar(chave, momento)), []byte(assinatura)) {
+		return ErrAssinaturaInvalida
+	}
+	return nil
+}
+
+func assinar(chave string, expira int64) string {
+	mac := hmac.New(sha256.New, config.SecretKey)
+	fmt.Fprintf(mac, "%s\n%d", chave, expira)
+	return hex.EncodeToString(mac.Sum(nil))
+}
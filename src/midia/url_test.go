@@ -0,0 +1,76 @@
+package midia
+
+import (
+	"api/src/config"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func configurarAssinatura(t *testing.T) {
+	t.Helper()
+	segredo, validade := config.SecretKey, config.MidiaValidadeURL
+	config.SecretKey, config.MidiaValidadeURL = []byte("segredo de teste"), time.Hour
+	t.Cleanup(func() { config.SecretKey, config.MidiaValidadeURL = segredo, validade })
+}
+
+func TestURLAssinadaValida(t *testing.T) {
+	configurarAssinatura(t)
+
+	endereco, erro := url.Parse(URLAssinada("anexos/1/foto.jpg"))
+	if erro != nil {
+		t.Fatal(erro)
+	}
+	if endereco.Path != "/midias/anexos/1/foto.jpg" {
+		t.Errorf("caminho = %q", endereco.Path)
+	}
+
+	expira, _ := strconv.ParseInt(endereco.Query().Get("expira"), 10, 64)
+	if restante := time.Until(time.Unix(expira, 0)); restante < config.MidiaValidadeURL {
+		t.Errorf("URL vale só por %v, want pelo menos %v", restante, config.MidiaValidadeURL)
+	}
+
+	if erro := VerificarAssinatura("anexos/1/foto.jpg", endereco.Query().Get("expira"), endereco.Query().Get("assinatura")); erro != nil {
+		t.Errorf("VerificarAssinatura = %v, want nil", erro)
+	}
+}
+
+func TestVerificarAssinaturaRecusa(t *testing.T) {
+	configurarAssinatura(t)
+
+	futuro := time.Now().Add(time.Hour).Unix()
+	passado := time.Now().Add(-time.Second).Unix()
+
+	casos := []struct {
+		nome, chave, expira, assinatura string
+	}{
+		{"expirada", "a.jpg", strconv.FormatInt(passado, 10), assinar("a.jpg", passado)},
+		{"outra chave", "b.jpg", strconv.FormatInt(futuro, 10), assinar("a.jpg", futuro)},
+		{"expiração alterada", "a.jpg", strconv.FormatInt(futuro+1, 10), assinar("a.jpg", futuro)},
+		{"expiração inválida", "a.jpg", "amanhã", assinar("a.jpg", futuro)},
+		{"assinatura vazia", "a.jpg", strconv.FormatInt(futuro, 10), ""},
+		{"assinatura em maiúsculas", "a.jpg", strconv.FormatInt(futuro, 10), strings.ToUpper(assinar("a.jpg", futuro))},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			if erro := VerificarAssinatura(caso.chave, caso.expira, caso.assinatura); !errors.Is(erro, ErrAssinaturaInvalida) {
+				t.Errorf("VerificarAssinatura = %v, want ErrAssinaturaInvalida", erro)
+			}
+		})
+	}
+}
+
+func TestVerificarAssinaturaOutroSegredo(t *testing.T) {
+	configurarAssinatura(t)
+	futuro := time.Now().Add(time.Hour).Unix()
+	assinatura := assinar("a.jpg", futuro)
+
+	config.SecretKey = []byte("segredo trocado")
+	if erro := VerificarAssinatura("a.jpg", strconv.FormatInt(futuro, 10), assinatura); !errors.Is(erro, ErrAssinaturaInvalida) {
+		t.Errorf("VerificarAssinatura com outro segredo = %v, want ErrAssinaturaInvalida", erro)
+	}
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Anexo representa uma imagem anexada a uma publicação.
+// As URLs são assinadas e expiram; peça a publicação de novo para renová-las
+type Anexo struct {
+	Id             uint64    `json:"id,omitempty"`
+	PublicacaoId   uint64    `json:"publicacaoId,omitempty"`
+	Tipo           string    `json:"tipo,omitempty"`
+	Largura        int       `json:"largura"`
+	Altura         int       `json:"altura"`
+	Tamanho        int       `json:"tamanho"`
+	Url            string    `json:"url,omitempty"`
+	UrlMiniatura   string    `json:"urlMiniatura,omitempty"`
+	Chave          string    `json:"-"`
+	ChaveMiniatura string    `json:"-"`
+	CriadoEm       time.Time `json:"CriadoEm,omitzero"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// FiltrosBusca restringem a busca de publicações além do texto
+type FiltrosBusca struct {
+	AutorId *uint64
+	// Desde e Ate limitam a data de publicação; Ate é exclusivo
+	Desde *time.Time
+	Ate   *time.Time
+	// Tag já vem normalizada, sem o #
+	Tag string
+}
+
+// Relevancia é a pontuação de uma publicação para uma busca. Pontuações de motores
+// de busca diferentes não são comparáveis entre si
+type Relevancia struct {
+	PublicacaoId uint64
+	Pontuacao    float64
+}
+
+// PublicacaoEncontrada é um resultado da busca de publicações
+type PublicacaoEncontrada struct {
+	Publicacao
+	// Trecho é a parte do texto em que os termos aparecem, com cada ocorrência entre <mark> e </mark>.
+	// O restante do texto vem escapado para HTML
+	Trecho    string  `json:"trecho"`
+	Pontuacao float64 `json:"pontuacao"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Comentario representa um comentário feito em uma publicação
+type Comentario struct {
+	Id           uint64        `json:"id,omitempty"`
+	PublicacaoId uint64        `json:"publicacaoId,omitempty"`
+	AutorId      uint64        `json:"autorId,omitempty"`
+	AutorNick    string        `json:"autorNick,omitempty"`
+	AutorAvatar  *ImagemPerfil `json:"autorAvatar,omitempty"`
+	Conteudo     string        `json:"conteudo,omitempty"`
+	CriadoEm     time.Time     `json:"CriadoEm,omitzero"`
+	AtualizadoEm *time.Time    `json:"atualizadoEm,omitempty"`
+}
+
+func (comentario *Comentario) Preparar() error {
+	if erro := comentario.validar(); erro != nil {
+		return erro
+	}
+
+	comentario.formatar()
+	return nil
+}
+
+func (comentario *Comentario) validar() error {
+	if strings.TrimSpace(comentario.Conteudo) == "" {
+		return errors.New("É obrigatório informar o conteudo")
+	}
+	if len([]rune(strings.TrimSpace(comentario.Conteudo))) > 300 {
+		return errors.New("O comentário deve ter no máximo 300 caracteres")
+	}
+	return nil
+}
+
+func (comentario *Comentario) formatar() {
+	comentario.Conteudo = strings.TrimSpace(comentario.Conteudo)
+}
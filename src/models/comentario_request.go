@@ -0,0 +1,5 @@
+package models
+
+type ComentarioRequest struct {
+	Conteudo string `json:"conteudo,omitempty"`
+}
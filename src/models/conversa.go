@@ -0,0 +1,15 @@
+package models
+
+// Conversa representa uma publicação com o contexto da thread em que ela está
+type Conversa struct {
+	// Ancestrais vai da raiz da conversa até a publicação respondida diretamente
+	Ancestrais []Publicacao       `json:"ancestrais"`
+	Publicacao Publicacao         `json:"publicacao"`
+	Respostas  []RespostaConversa `json:"respostas"`
+}
+
+// RespostaConversa é um nó da árvore de respostas de uma conversa
+type RespostaConversa struct {
+	Publicacao Publicacao         `json:"publicacao"`
+	Respostas  []RespostaConversa `json:"respostas"`
+}
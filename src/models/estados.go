@@ -0,0 +1,31 @@
+package models
+
+// EstadoCurtida é o resultado de curtir ou descurtir uma publicação
+type EstadoCurtida struct {
+	PublicacaoId uint64 `json:"publicacaoId"`
+	Curtido      bool   `json:"curtido"`
+	Curtidas     uint64 `json:"curtidas"`
+}
+
+// EstadoSeguir é o resultado de seguir ou deixar de seguir um usuário.
+// Solicitado indica que há uma solicitação pendente para seguir uma conta privada
+type EstadoSeguir struct {
+	UsuarioId  uint64 `json:"usuarioId"`
+	Seguindo   bool   `json:"seguindo"`
+	Solicitado bool   `json:"solicitado"`
+	Seguidores uint64 `json:"seguidores"`
+}
+
+// Relacao resume o vínculo entre o usuário autenticado e outro usuário, para montar os botões do perfil.
+// Se o outro usuário silenciou o autenticado não aparece: o silêncio é invisível para quem foi silenciado
+type Relacao struct {
+	UsuarioId         uint64 `json:"usuarioId"`
+	Seguindo          bool   `json:"seguindo"`
+	SegueVoce         bool   `json:"segueVoce"`
+	Solicitado        bool   `json:"solicitado"`
+	SolicitouVoce     bool   `json:"solicitouVoce"`
+	Bloqueado         bool   `json:"bloqueado"`
+	BloqueiaVoce      bool   `json:"bloqueiaVoce"`
+	Silenciado        bool   `json:"silenciado"`
+	SeguidoresEmComum uint64 `json:"seguidoresEmComum"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// Tipos de evento enviados em tempo real
+const (
+	// EventoPublicacao é um item novo no feed: publicação ou republicação
+	EventoPublicacao = "publicacao"
+	// EventoReacoes é a mudança na contagem de curtidas e reações de uma publicação
+	EventoReacoes = "reacoes"
+	// EventoNotificacao avisa que o usuário tem uma notificação nova ou atualizada
+	EventoNotificacao = "notificacao"
+	// EventoMensagem é uma mensagem direta nova em uma conversa do usuário
+	EventoMensagem = "mensagem"
+	// EventoRelacao avisa que mudou quem o usuário segue, silencia ou bloqueia. Serve para a conexão
+	// do usuário atualizar os autores que acompanha e não é repassado ao cliente
+	EventoRelacao = "relacao"
+	// EventoReiniciar pede ao cliente que recarregue tudo, pois não dá para retomar do último evento recebido
+	EventoReiniciar = "reiniciar"
+)
+
+// Evento é o que é enviado pelo stream de tempo real. Com UsuarioId, vai só para esse usuário;
+// sem ele, vai para quem segue AutorId e para o próprio autor
+type Evento struct {
+	Id        uint64    `json:"id"`
+	Tipo      string    `json:"tipo"`
+	UsuarioId *uint64   `json:"-"`
+	AutorId   *uint64   `json:"-"`
+	Dados     any       `json:"dados"`
+	CriadoEm  time.Time `json:"criadoEm"`
+}
+
+// DadosEventoPublicacao é o que fica gravado no evento de publicação.
+// Na entrega ele é trocado pela publicação completa
+type DadosEventoPublicacao struct {
+	PublicacaoId   uint64  `json:"publicacaoId"`
+	RepublicadaPor *uint64 `json:"republicadaPor,omitempty"`
+}
+
+// DadosEventoReacoes é a contagem atual de reações de uma publicação
+type DadosEventoReacoes struct {
+	PublicacaoId uint64            `json:"publicacaoId"`
+	Curtidas     uint64            `json:"curtidas"`
+	Reacoes      map[string]uint64 `json:"reacoes"`
+}
+
+// DadosEventoNotificacao identifica a notificação e traz a contagem atual de não lidas
+type DadosEventoNotificacao struct {
+	NotificacaoId uint64               `json:"notificacaoId"`
+	Tipo          string               `json:"tipo"`
+	NaoLidas      ContagemNotificacoes `json:"naoLidas"`
+}
+
+// Destinado informa se o evento deve ser entregue ao usuário, que segue os autores em seguindo
+func (evento Evento) Destinado(usuarioId uint64, seguindo map[uint64]bool) bool {
+	if evento.UsuarioId != nil {
+		return *evento.UsuarioId == usuarioId
+	}
+	if evento.AutorId != nil {
+		return *evento.AutorId == usuarioId || seguindo[*evento.AutorId]
+	}
+	return true
+}
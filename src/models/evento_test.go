@@ -0,0 +1,37 @@
+package models
+
+import "testing"
+
+func TestEventoDestinado(t *testing.T) {
+	id := func(valor uint64) *uint64 { return &valor }
+	seguindo := map[uint64]bool{2: true}
+
+	casos := []struct {
+		nome   string
+		evento Evento
+		want   bool
+	}{
+		{"para o próprio usuário", Evento{UsuarioId: id(1)}, true},
+		{"para outro usuário", Evento{UsuarioId: id(3)}, false},
+		{"usuário tem precedência sobre o autor", Evento{UsuarioId: id(3), AutorId: id(2)}, false},
+		{"autor seguido", Evento{AutorId: id(2)}, true},
+		{"o próprio autor", Evento{AutorId: id(1)}, true},
+		{"autor não seguido", Evento{AutorId: id(4)}, false},
+		{"sem destino", Evento{}, true},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			if got := caso.evento.Destinado(1, seguindo); got != caso.want {
+				t.Errorf("Destinado(1) = %v, want %v", got, caso.want)
+			}
+		})
+	}
+}
+
+func TestEventoDestinadoSemSeguindo(t *testing.T) {
+	autor := uint64(2)
+	if (Evento{AutorId: &autor}).Destinado(1, nil) {
+		t.Error("evento de autor entregue a quem não segue ninguém")
+	}
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// PesosFeed configuram a pontuação do feed por relevância
+type PesosFeed struct {
+	// Janela é até quanto tempo atrás as publicações entram no feed
+	Janela time.Duration
+	// MeiaVida é o tempo para uma publicação passar a valer metade
+	MeiaVida time.Duration
+	// Engajamento multiplica o logaritmo de reações mais comentários da publicação
+	Engajamento float64
+	// Afinidade multiplica o logaritmo das interações recentes do usuário com o autor
+	Afinidade float64
+	// SegundoGrau multiplica a pontuação das publicações de quem o usuário não segue, mas quem ele segue segue
+	SegundoGrau float64
+}
@@ -0,0 +1,10 @@
+package models
+
+// ImagemPerfil traz as URLs assinadas de um avatar ou banner em cada tamanho padrão.
+// Avatares têm pequeno (48x48), medio (128x128) e grande (400x400);
+// banners têm pequeno (600x200) e grande (1500x500)
+type ImagemPerfil struct {
+	Pequeno string `json:"pequeno,omitempty"`
+	Medio   string `json:"medio,omitempty"`
+	Grande  string `json:"grande,omitempty"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"regexp"
+	"unicode/utf8"
+)
+
+// expressaoMencao encontra @nick no início do texto ou depois de um caractere que não
+// faça parte de nick, para que e-mails (fulano@site.com) não virem menções.
+// Um ponto no fim ("fala @joao.") é pontuação, não parte do nick
+var expressaoMencao = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.@])@([\p{L}\p{N}_.]*[\p{L}\p{N}_])`)
+
+// Mencao é um @nick do conteúdo resolvido para um usuário. Inicio e Fim são posições em
+// caracteres (não bytes) no conteudo, do @ até o fim do nick. Nick é o nick atual do
+// usuário, que pode ser diferente do texto se ele tiver trocado de nick depois
+type Mencao struct {
+	UsuarioId uint64 `json:"usuarioId"`
+	Nick      string `json:"nick"`
+	Inicio    int    `json:"inicio"`
+	Fim       int    `json:"fim"`
+}
+
+// ExtrairMencoes retorna as menções do conteúdo com o nick como foi escrito e sem
+// UsuarioId, que é preenchido quando o nick é encontrado no banco
+func ExtrairMencoes(conteudo string) []Mencao {
+	mencoes := []Mencao{}
+	for _, posicao := range expressaoMencao.FindAllStringSubmatchIndex(conteudo, -1) {
+		// posicao[2]:posicao[3] é o nick; o @ fica logo antes
+		inicio := utf8.RuneCountInString(conteudo[:posicao[2]-1])
+		nick := conteudo[posicao[2]:posicao[3]]
+		mencoes = append(mencoes, Mencao{
+			Nick:   nick,
+			Inicio: inicio,
+			Fim:    inicio + 1 + utf8.RuneCountInString(nick),
+		})
+	}
+	return mencoes
+}
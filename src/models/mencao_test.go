@@ -0,0 +1,33 @@
+package models
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExtrairMencoes(t *testing.T) {
+	casos := []struct {
+		nome     string
+		conteudo string
+		want     []Mencao
+	}{
+		{"no começo", "@ana oi", []Mencao{{Nick: "ana", Inicio: 0, Fim: 4}}},
+		{"no meio", "oi @ana", []Mencao{{Nick: "ana", Inicio: 3, Fim: 7}}},
+		{"posições em caracteres", "ação @joão!", []Mencao{{Nick: "joão", Inicio: 5, Fim: 10}}},
+		{"depois de emoji", "😀 @bia", []Mencao{{Nick: "bia", Inicio: 2, Fim: 6}}},
+		{"ponto final", "fala @joao.", []Mencao{{Nick: "joao", Inicio: 5, Fim: 10}}},
+		{"ponto no nick", "@a.b oi", []Mencao{{Nick: "a.b", Inicio: 0, Fim: 4}}},
+		{"várias", "@ana e @bia", []Mencao{{Nick: "ana", Inicio: 0, Fim: 4}, {Nick: "bia", Inicio: 7, Fim: 11}}},
+		{"e-mail", "fulano@site.com", []Mencao{}},
+		{"@@", "@@ana", []Mencao{}},
+		{"@ sozinho", "eu @ casa", []Mencao{}},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			if got := ExtrairMencoes(caso.conteudo); !slices.Equal(got, caso.want) {
+				t.Errorf("ExtrairMencoes(%q) = %+v, want %+v", caso.conteudo, got, caso.want)
+			}
+		})
+	}
+}
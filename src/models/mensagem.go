@@ -0,0 +1,119 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Limites das mensagens diretas
+const (
+	// MaximoParticipantesConversa conta o próprio criador da conversa
+	MaximoParticipantesConversa = 10
+	tamanhoMaximoNomeConversa   = 50
+	tamanhoMaximoMensagem       = 1000
+)
+
+// ConversaDireta é uma conversa privada entre duas pessoas ou um grupo pequeno.
+// UltimaMensagem e NaoLidas são do ponto de vista de quem busca
+type ConversaDireta struct {
+	Id             uint64                 `json:"id"`
+	Grupo          bool                   `json:"grupo"`
+	Nome           string                 `json:"nome,omitempty"`
+	Participantes  []ParticipanteConversa `json:"participantes"`
+	UltimaMensagem *Mensagem              `json:"ultimaMensagem,omitempty"`
+	NaoLidas       uint64                 `json:"naoLidas"`
+	AtualizadaEm   time.Time              `json:"atualizadaEm"`
+}
+
+// ParticipanteConversa é um membro da conversa. UltimaLidaId é a última mensagem que ele leu
+type ParticipanteConversa struct {
+	UsuarioId    uint64        `json:"usuarioId"`
+	Nick         string        `json:"nick"`
+	Avatar       *ImagemPerfil `json:"avatar,omitempty"`
+	UltimaLidaId *uint64       `json:"ultimaLidaId,omitempty"`
+}
+
+// Mensagem é uma mensagem enviada em uma conversa direta
+type Mensagem struct {
+	Id         uint64    `json:"id"`
+	ConversaId uint64    `json:"conversaId"`
+	AutorId    uint64    `json:"autorId"`
+	AutorNick  string    `json:"autorNick"`
+	Texto      string    `json:"texto"`
+	CriadaEm   time.Time `json:"criadaEm"`
+}
+
+// PaginaMensagens traz as mensagens da mais recente para a mais antiga. ProximoCursor vai no
+// parâmetro antes para buscar as anteriores e não vem quando não há mais nada
+type PaginaMensagens struct {
+	Mensagens     []Mensagem `json:"mensagens"`
+	ProximoCursor *uint64    `json:"proximoCursor,omitempty"`
+}
+
+// ContagemMensagens traz quantas mensagens não lidas o usuário tem e em quantas conversas
+type ContagemMensagens struct {
+	NaoLidas  uint64 `json:"naoLidas"`
+	Conversas uint64 `json:"conversas"`
+}
+
+// ConversaDiretaRequest cria uma conversa com os participantes informados, além de quem cria.
+// Com um só participante a conversa é entre duas pessoas e, se já existir, é reaproveitada
+type ConversaDiretaRequest struct {
+	Participantes []uint64 `json:"participantes"`
+	Nome          string   `json:"nome,omitempty"`
+}
+
+// Preparar tira repetições e o próprio criador dos participantes e valida o tamanho do grupo
+func (request *ConversaDiretaRequest) Preparar(criadorId uint64) error {
+	participantes := []uint64{}
+	for _, id := range request.Participantes {
+		if id != criadorId && !slices.Contains(participantes, id) {
+			participantes = append(participantes, id)
+		}
+	}
+	request.Participantes = participantes
+	request.Nome = strings.TrimSpace(request.Nome)
+
+	if len(request.Participantes) == 0 {
+		return errors.New("Informe ao menos um participante além de você")
+	}
+	if len(request.Participantes)+1 > MaximoParticipantesConversa {
+		return fmt.Errorf("Uma conversa pode ter no máximo %d participantes", MaximoParticipantesConversa)
+	}
+	if utf8.RuneCountInString(request.Nome) > tamanhoMaximoNomeConversa {
+		return fmt.Errorf("O nome da conversa pode ter no máximo %d caracteres", tamanhoMaximoNomeConversa)
+	}
+	if request.Nome != "" && !request.Grupo() {
+		return errors.New("Só conversas em grupo podem ter nome")
+	}
+
+	return nil
+}
+
+// Grupo informa se a conversa pedida tem mais de duas pessoas
+func (request ConversaDiretaRequest) Grupo() bool {
+	return len(request.Participantes) > 1
+}
+
+// MensagemRequest é o corpo do envio de uma mensagem
+type MensagemRequest struct {
+	Texto string `json:"texto"`
+}
+
+// Preparar valida o texto da mensagem, sem os espaços das pontas
+func (request *MensagemRequest) Preparar() error {
+	request.Texto = strings.TrimSpace(request.Texto)
+
+	if request.Texto == "" {
+		return errors.New("A mensagem não pode ficar em branco")
+	}
+	if utf8.RuneCountInString(request.Texto) > tamanhoMaximoMensagem {
+		return fmt.Errorf("A mensagem pode ter no máximo %d caracteres", tamanhoMaximoMensagem)
+	}
+
+	return nil
+}
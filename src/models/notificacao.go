@@ -0,0 +1,99 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Tipos de notificação
+const (
+	NotificacaoSeguir      = "seguir"
+	NotificacaoCurtida     = "curtida"
+	NotificacaoReacao      = "reacao"
+	NotificacaoComentario  = "comentario"
+	NotificacaoMencao      = "mencao"
+	NotificacaoSolicitacao = "solicitacao"
+)
+
+// TiposNotificacao são todos os tipos, na ordem em que aparecem nas preferências
+var TiposNotificacao = []string{
+	NotificacaoSeguir, NotificacaoCurtida, NotificacaoReacao, NotificacaoComentario, NotificacaoMencao,
+	NotificacaoSolicitacao,
+}
+
+// Notificacao agrupa as ações do mesmo tipo sobre o mesmo alvo enquanto não é lida:
+// dez curtidas na mesma publicação viram uma notificação com TotalAtores 10
+type Notificacao struct {
+	Id           uint64            `json:"id"`
+	Tipo         string            `json:"tipo"`
+	PublicacaoId *uint64           `json:"publicacaoId,omitempty"`
+	Atores       []AtorNotificacao `json:"atores"`
+	TotalAtores  uint64            `json:"totalAtores"`
+	Texto        string            `json:"texto"`
+	Lida         bool              `json:"lida"`
+	AtualizadaEm time.Time         `json:"atualizadaEm"`
+}
+
+// AtorNotificacao é quem fez a ação notificada. Atores traz os mais recentes primeiro
+type AtorNotificacao struct {
+	UsuarioId uint64        `json:"usuarioId"`
+	Nick      string        `json:"nick"`
+	Avatar    *ImagemPerfil `json:"avatar,omitempty"`
+}
+
+// ContagemNotificacoes traz quantas notificações não lidas o usuário tem, no total e por tipo
+type ContagemNotificacoes struct {
+	NaoLidas uint64            `json:"naoLidas"`
+	PorTipo  map[string]uint64 `json:"porTipo"`
+}
+
+// PreferenciasNotificacao indica, por tipo, se o usuário quer receber a notificação
+type PreferenciasNotificacao map[string]bool
+
+// Validar confere se todos os tipos informados existem
+func (preferencias PreferenciasNotificacao) Validar() error {
+	if len(preferencias) == 0 {
+		return errors.New("Informe ao menos um tipo de notificação")
+	}
+	for tipo := range preferencias {
+		if !slices.Contains(TiposNotificacao, tipo) {
+			return fmt.Errorf("Tipo de notificação inválido. Tipos permitidos: %s", strings.Join(TiposNotificacao, ", "))
+		}
+	}
+	return nil
+}
+
+// verbosNotificacao são os verbos de cada tipo no singular e no plural
+var verbosNotificacao = map[string][2]string{
+	NotificacaoSeguir:      {"começou a seguir você", "começaram a seguir você"},
+	NotificacaoCurtida:     {"curtiu sua publicação", "curtiram sua publicação"},
+	NotificacaoReacao:      {"reagiu à sua publicação", "reagiram à sua publicação"},
+	NotificacaoComentario:  {"comentou na sua publicação", "comentaram na sua publicação"},
+	NotificacaoMencao:      {"mencionou você em uma publicação", "mencionaram você em uma publicação"},
+	NotificacaoSolicitacao: {"pediu para seguir você", "pediram para seguir você"},
+}
+
+// MontarTexto preenche Texto a partir dos atores: "ana curtiu", "ana e bia curtiram",
+// "ana e mais 4 pessoas curtiram"
+func (notificacao *Notificacao) MontarTexto() {
+	if len(notificacao.Atores) == 0 {
+		notificacao.Texto = ""
+		return
+	}
+
+	verbos := verbosNotificacao[notificacao.Tipo]
+	primeiro := "@" + notificacao.Atores[0].Nick
+	switch outros := notificacao.TotalAtores - 1; {
+	case outros == 0:
+		notificacao.Texto = fmt.Sprintf("%s %s", primeiro, verbos[0])
+	case outros == 1 && len(notificacao.Atores) > 1:
+		notificacao.Texto = fmt.Sprintf("%s e @%s %s", primeiro, notificacao.Atores[1].Nick, verbos[1])
+	case outros == 1:
+		notificacao.Texto = fmt.Sprintf("%s e mais 1 pessoa %s", primeiro, verbos[1])
+	default:
+		notificacao.Texto = fmt.Sprintf("%s e mais %d pessoas %s", primeiro, outros, verbos[1])
+	}
+}
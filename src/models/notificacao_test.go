@@ -0,0 +1,32 @@
+package models
+
+import "testing"
+
+func TestMontarTexto(t *testing.T) {
+	ana := AtorNotificacao{UsuarioId: 1, Nick: "ana"}
+	bia := AtorNotificacao{UsuarioId: 2, Nick: "bia"}
+
+	casos := []struct {
+		nome        string
+		tipo        string
+		atores      []AtorNotificacao
+		totalAtores uint64
+		want        string
+	}{
+		{"um ator", NotificacaoCurtida, []AtorNotificacao{ana}, 1, "@ana curtiu sua publicação"},
+		{"dois atores", NotificacaoCurtida, []AtorNotificacao{ana, bia}, 2, "@ana e @bia curtiram sua publicação"},
+		{"dois atores, só um carregado", NotificacaoSeguir, []AtorNotificacao{ana}, 2, "@ana e mais 1 pessoa começaram a seguir você"},
+		{"vários atores", NotificacaoComentario, []AtorNotificacao{ana, bia}, 5, "@ana e mais 4 pessoas comentaram na sua publicação"},
+		{"sem atores", NotificacaoMencao, nil, 0, ""},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			notificacao := Notificacao{Tipo: caso.tipo, Atores: caso.atores, TotalAtores: caso.totalAtores, Texto: "antigo"}
+			notificacao.MontarTexto()
+			if notificacao.Texto != caso.want {
+				t.Errorf("Texto = %q, want %q", notificacao.Texto, caso.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package models
+
+// Paginacao representa a página e o tamanho de página pedidos em listagens
+type Paginacao struct {
+	Pagina uint64
+	Limite uint64
+}
+
+// Offset retorna quantos registros devem ser pulados para chegar na página
+func (paginacao Paginacao) Offset() uint64 {
+	if paginacao.Pagina == 0 {
+		return 0
+	}
+	return (paginacao.Pagina - 1) * paginacao.Limite
+}
@@ -7,15 +7,73 @@ import (
 )
 
 type Publicacao struct {
-	Id        uint64    `json:"id,omitempty"`
-	Titulo    string    `json:"titulo,omitempty"`
-	Conteudo  string    `json:"conteudo,omitempty"`
-	AutorId   uint64    `json:"autorId,omitempty"`
-	AutorNick string    `json:"autorNick,omitempty"`
-	Curtidas  uint64    `json:"curtidas"`
-	CriadaEm  time.Time `json:"CriadaEm,omitzero"`
+	Id          uint64        `json:"id,omitempty"`
+	Titulo      string        `json:"titulo,omitempty"`
+	Conteudo    string        `json:"conteudo,omitempty"`
+	AutorId     uint64        `json:"autorId,omitempty"`
+	AutorNick   string        `json:"autorNick,omitempty"`
+	AutorAvatar *ImagemPerfil `json:"autorAvatar,omitempty"`
+	EmRespostaA *uint64       `json:"emRespostaA,omitempty"`
+	CitacaoDe   *uint64       `json:"citacaoDe,omitempty"`
+	// Citacao é a publicação embutida quando esta é uma citação
+	Citacao  *Publicacao `json:"citacao,omitempty"`
+	Curtidas uint64      `json:"curtidas"`
+	// Reacoes traz a quantidade de reações por tipo
+	Reacoes       map[string]uint64 `json:"reacoes"`
+	Comentarios   uint64            `json:"comentarios"`
+	Respostas     uint64            `json:"respostas"`
+	Republicacoes uint64            `json:"republicacoes"`
+	Citacoes      uint64            `json:"citacoes"`
+	// RepublicadaPor é preenchido quando o item do feed é uma republicação
+	RepublicadaPor *Republicacao `json:"republicadaPor,omitempty"`
+	CriadaEm       time.Time     `json:"CriadaEm,omitzero"`
+	EditadaEm      *time.Time    `json:"editadaEm,omitempty"`
+	Edicoes        uint64        `json:"edicoes"`
+	Anexos         []Anexo       `json:"anexos,omitempty"`
+	Status         string        `json:"status,omitempty"`
+	PublicarEm     *time.Time    `json:"publicarEm,omitempty"`
+	// Visibilidade define quem, além do autor, pode ver a publicação
+	Visibilidade string `json:"visibilidade,omitempty"`
+	// Tags são as hashtags do título e do conteúdo, extraídas em Preparar
+	Tags []string `json:"tags,omitempty"`
+	// Mencoes são os @nick do conteúdo que correspondem a usuários
+	Mencoes []Mencao `json:"mencoes,omitempty"`
+	// Fixada indica a publicação fixada no topo do perfil do autor
+	Fixada bool `json:"fixada,omitempty"`
+	// Os campos a seguir dizem respeito ao usuário autenticado. Nos eventos em tempo real,
+	// que vão para vários usuários, eles vêm zerados
+
+	// Salvo indica se o usuário autenticado salvou a publicação
+	Salvo bool `json:"salvo"`
+	// CurtidoPorMim indica se o usuário autenticado curtiu a publicação
+	CurtidoPorMim bool `json:"curtidoPorMim"`
+	// MinhaReacao é o tipo da reação do usuário autenticado, se houver
+	MinhaReacao string `json:"minhaReacao,omitempty"`
+	// EditadaAposMinhaReacao indica se a publicação foi editada depois que o usuário autenticado reagiu a ela
+	EditadaAposMinhaReacao bool `json:"editadaAposMinhaReacao"`
+	// RepublicadoPorMim indica se o usuário autenticado republicou a publicação
+	RepublicadoPorMim bool `json:"republicadoPorMim"`
+	// SigoAutor indica se o usuário autenticado segue o autor
+	SigoAutor bool `json:"sigoAutor"`
+	// Minha indica se o usuário autenticado é o autor
+	Minha bool `json:"minha"`
 }
 
+// Status possíveis de uma publicação. Só as publicadas aparecem para outros usuários
+const (
+	StatusRascunho  = "rascunho"
+	StatusAgendada  = "agendada"
+	StatusPublicada = "publicada"
+)
+
+// Visibilidades possíveis de uma publicação. Publicações de contas privadas nunca passam
+// dos seguidores, qualquer que seja a visibilidade, a não ser para os mencionados
+const (
+	VisibilidadePublica     = "publica"
+	VisibilidadeSeguidores  = "seguidores"
+	VisibilidadeMencionados = "mencionados"
+)
+
 func (publicacao *Publicacao) Preparar() error {
 	if erro := publicacao.validar(); erro != nil {
 		return erro
@@ -33,10 +91,41 @@ func (publicacao *Publicacao) validar() error {
 	if publicacao.Conteudo == "" {
 		return errors.New("É obrigatório informar o conteudo")
 	}
+
+	switch publicacao.Status {
+	case "", StatusRascunho, StatusPublicada:
+	case StatusAgendada:
+		if publicacao.PublicarEm == nil {
+			return errors.New("É obrigatório informar publicarEm para agendar a publicação")
+		}
+		if !publicacao.PublicarEm.After(time.Now()) {
+			return errors.New("publicarEm deve estar no futuro")
+		}
+	default:
+		return errors.New("Status inválido. Use rascunho, agendada ou publicada")
+	}
+
+	switch publicacao.Visibilidade {
+	case "", VisibilidadePublica, VisibilidadeSeguidores, VisibilidadeMencionados:
+	default:
+		return errors.New("Visibilidade inválida. Use publica, seguidores ou mencionados")
+	}
 	return nil
 }
 
 func (publicacao *Publicacao) formatar() {
 	publicacao.Titulo = strings.TrimSpace(publicacao.Titulo)
 	publicacao.Conteudo = strings.TrimSpace(publicacao.Conteudo)
+	publicacao.Tags = ExtrairTags(publicacao.Titulo, publicacao.Conteudo)
+	publicacao.Mencoes = ExtrairMencoes(publicacao.Conteudo)
+
+	if publicacao.Status == "" {
+		publicacao.Status = StatusPublicada
+	}
+	if publicacao.Visibilidade == "" {
+		publicacao.Visibilidade = VisibilidadePublica
+	}
+	if publicacao.Status != StatusAgendada {
+		publicacao.PublicarEm = nil
+	}
 }
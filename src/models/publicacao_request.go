@@ -1,6 +1,18 @@
 package models
 
+import "time"
+
 type PublicacaoRequest struct {
 	Titulo   string `json:"titulo,omitempty"`
 	Conteudo string `json:"conteudo,omitempty"`
+	// EmRespostaA é o id da publicação respondida. Só é lido na criação
+	EmRespostaA *uint64 `json:"emRespostaA,omitempty"`
+	// CitacaoDe é o id da publicação citada. Só é lido na criação
+	CitacaoDe *uint64 `json:"citacaoDe,omitempty"`
+	// Status é rascunho, agendada ou publicada (padrão). Só é lido na criação e em /rascunhos
+	Status string `json:"status,omitempty"`
+	// PublicarEm é obrigatório quando o status é agendada
+	PublicarEm *time.Time `json:"publicarEm,omitempty"`
+	// Visibilidade é publica (padrão), seguidores ou mencionados. Só é lida na criação e em /rascunhos
+	Visibilidade string `json:"visibilidade,omitempty"`
 }
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ReacaoCurtir é o tipo de reação usado pela curtida. Ele sempre faz parte dos tipos permitidos
+const ReacaoCurtir = "curtir"
+
+// Reacao representa a reação de um usuário a uma publicação
+type Reacao struct {
+	UsuarioId     uint64        `json:"usuarioId,omitempty"`
+	UsuarioNick   string        `json:"usuarioNick,omitempty"`
+	UsuarioAvatar *ImagemPerfil `json:"usuarioAvatar,omitempty"`
+	Tipo          string        `json:"tipo,omitempty"`
+	CriadaEm      time.Time     `json:"CriadaEm,omitzero"`
+}
+
+type ReacaoRequest struct {
+	Tipo string `json:"tipo,omitempty"`
+}
+
+// Preparar normaliza o tipo e confere se ele está entre os tipos permitidos
+func (reacao *ReacaoRequest) Preparar(tiposPermitidos []string) error {
+	reacao.Tipo = strings.ToLower(strings.TrimSpace(reacao.Tipo))
+
+	if reacao.Tipo == "" {
+		return errors.New("É obrigatório informar o tipo da reação")
+	}
+	if !slices.Contains(tiposPermitidos, reacao.Tipo) {
+		return fmt.Errorf("Tipo de reação inválido. Tipos permitidos: %s", strings.Join(tiposPermitidos, ", "))
+	}
+	return nil
+}
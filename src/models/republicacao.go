@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// Republicacao indica quem compartilhou uma publicação no feed e quando
+type Republicacao struct {
+	UsuarioId     uint64        `json:"usuarioId"`
+	UsuarioNick   string        `json:"usuarioNick"`
+	UsuarioAvatar *ImagemPerfil `json:"usuarioAvatar,omitempty"`
+	CriadaEm      time.Time     `json:"CriadaEm"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Revisao é uma versão do título e do conteúdo de uma publicação.
+// A revisão 1 é o texto original e a última é o texto atual
+type Revisao struct {
+	Numero   uint64    `json:"numero"`
+	Titulo   string    `json:"titulo"`
+	Conteudo string    `json:"conteudo"`
+	CriadaEm time.Time `json:"CriadaEm"`
+	// DiffTitulo e DiffConteudo comparam com a revisão anterior, marcando
+	// o que saiu com [-...-] e o que entrou com {+...+}
+	DiffTitulo   string `json:"diffTitulo,omitempty"`
+	DiffConteudo string `json:"diffConteudo,omitempty"`
+}
+
+// HistoricoPublicacao reúne as revisões de uma publicação e, para quem consulta,
+// se ela mudou depois que essa pessoa reagiu a ela
+type HistoricoPublicacao struct {
+	PublicacaoId           uint64     `json:"publicacaoId"`
+	Edicoes                uint64     `json:"edicoes"`
+	EditadaEm              *time.Time `json:"editadaEm,omitempty"`
+	MinhaReacaoEm          *time.Time `json:"minhaReacaoEm,omitempty"`
+	EditadaAposMinhaReacao bool       `json:"editadaAposMinhaReacao"`
+	Revisoes               []Revisao  `json:"revisoes"`
+}
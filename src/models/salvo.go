@@ -0,0 +1,42 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// tamanhoMaximoColecao acompanha a coluna colecoes.nome
+const tamanhoMaximoColecao = 50
+
+// Colecao agrupa publicações salvas. Só o dono vê as coleções e o que há nelas
+type Colecao struct {
+	Id       uint64    `json:"id,omitempty"`
+	Nome     string    `json:"nome,omitempty"`
+	Salvos   uint64    `json:"salvos"`
+	CriadaEm time.Time `json:"criadaEm,omitzero"`
+}
+
+type ColecaoRequest struct {
+	Nome string `json:"nome,omitempty"`
+}
+
+// Preparar remove os espaços das pontas do nome e confere se ele é válido
+func (colecao *ColecaoRequest) Preparar() error {
+	colecao.Nome = strings.TrimSpace(colecao.Nome)
+
+	if colecao.Nome == "" {
+		return errors.New("É obrigatório informar o nome da coleção")
+	}
+	if utf8.RuneCountInString(colecao.Nome) > tamanhoMaximoColecao {
+		return errors.New("O nome da coleção deve ter no máximo 50 caracteres")
+	}
+	return nil
+}
+
+// SalvarRequest é o corpo opcional de PUT /publicacoes/{id}/salvo
+type SalvarRequest struct {
+	// ColecaoId é a coleção onde a publicação fica. Sem ela, a publicação fica fora de coleções
+	ColecaoId *uint64 `json:"colecaoId,omitempty"`
+}
@@ -0,0 +1,10 @@
+package models
+
+// Sugestao é uma conta recomendada para o usuário seguir, com o motivo da recomendação
+type Sugestao struct {
+	Usuario Usuario `json:"usuario"`
+	// SeguidoresEmComum é quantas das contas que o usuário segue seguem a sugerida
+	SeguidoresEmComum uint64 `json:"seguidoresEmComum"`
+	// SegueVoce indica que a conta sugerida já segue o usuário
+	SegueVoce bool `json:"segueVoce"`
+}
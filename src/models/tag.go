@@ -0,0 +1,45 @@
+package models
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// tamanhoMaximoTag acompanha a coluna tags.nome
+const tamanhoMaximoTag = 50
+
+// expressaoTag encontra #palavra no início do texto ou depois de um caractere que
+// não faça parte de palavra, para que "a#b" e URLs com âncora não virem tags
+var expressaoTag = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_&/#])#([\p{L}\p{N}_]+)`)
+
+// TagEmAlta é uma tag do ranking de tendências
+type TagEmAlta struct {
+	Tag         string  `json:"tag"`
+	Publicacoes uint64  `json:"publicacoes"`
+	Pontuacao   float64 `json:"pontuacao"`
+}
+
+// ExtrairTags retorna as hashtags dos textos, sem o #, em minúsculas e sem repetição,
+// na ordem em que aparecem. Tags só com números (#1) são ignoradas
+func ExtrairTags(textos ...string) []string {
+	tags := []string{}
+	for _, texto := range textos {
+		for _, encontrada := range expressaoTag.FindAllStringSubmatch(texto, -1) {
+			tag := NormalizarTag(encontrada[1])
+			if !strings.ContainsFunc(tag, unicode.IsLetter) || len([]rune(tag)) > tamanhoMaximoTag {
+				continue
+			}
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// NormalizarTag deixa a tag no formato guardado no banco, aceitando-a com ou sem #
+func NormalizarTag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExtrairTags(t *testing.T) {
+	casos := []struct {
+		nome   string
+		textos []string
+		want   []string
+	}{
+		{"simples", []string{"indo ao #Show hoje"}, []string{"show"}},
+		{"repetida em outra caixa", []string{"#Go e #go e #GO"}, []string{"go"}},
+		{"ordem de aparição", []string{"#b #a", "#c #a"}, []string{"b", "a", "c"}},
+		{"acentos", []string{"#Ação #café"}, []string{"ação", "café"}},
+		{"pontuação em volta", []string{"(#tag), #outra."}, []string{"tag", "outra"}},
+		{"colada em palavra", []string{"a#b"}, []string{}},
+		{"âncora de URL", []string{"http://site.com/#ancora"}, []string{}},
+		{"entidade HTML", []string{"&#39;"}, []string{}},
+		{"só números", []string{"#1 #2024"}, []string{}},
+		{"número com letra", []string{"#2024copa"}, []string{"2024copa"}},
+		{"##", []string{"##dupla"}, []string{}},
+		{"grande demais", []string{"#" + strings.Repeat("a", tamanhoMaximoTag+1)}, []string{}},
+		{"no limite", []string{"#" + strings.Repeat("a", tamanhoMaximoTag)}, []string{strings.Repeat("a", tamanhoMaximoTag)}},
+		{"sem textos", nil, []string{}},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			if got := ExtrairTags(caso.textos...); !slices.Equal(got, caso.want) {
+				t.Errorf("ExtrairTags(%q) = %q, want %q", caso.textos, got, caso.want)
+			}
+		})
+	}
+}
+
+func TestNormalizarTag(t *testing.T) {
+	casos := []struct {
+		tag, want string
+	}{
+		{"go", "go"},
+		{"#Go", "go"},
+		{"  #Ação ", "ação"},
+		{"##go", "#go"},
+	}
+
+	for _, caso := range casos {
+		if got := NormalizarTag(caso.tag); got != caso.want {
+			t.Errorf("NormalizarTag(%q) = %q, want %q", caso.tag, got, caso.want)
+		}
+	}
+}
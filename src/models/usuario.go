@@ -3,20 +3,55 @@ package models
 import (
 	"api/src/security"
 	"errors"
+	"fmt"
+	"net/url"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/badoux/checkmail"
 )
 
 // Usuario representa o usuario utilizado na plataforma
 type Usuario struct {
-	Id       uint64    `json:"id,omitempty"`
-	Nome     string    `json:"nome,omitempty"`
-	Nick     string    `json:"nick,omitempty"`
-	Email    string    `json:"email,omitempty"`
-	Senha    string    `json:"senha,omitempty"`
-	CriadoEm time.Time `json:"CriadoEm,omitzero"`
+	Id          uint64        `json:"id,omitempty"`
+	Nome        string        `json:"nome,omitempty"`
+	Nick        string        `json:"nick,omitempty"`
+	Email       string        `json:"email,omitempty"`
+	Senha       string        `json:"senha,omitempty"`
+	CriadoEm    time.Time     `json:"CriadoEm,omitzero"`
+	Avatar      *ImagemPerfil `json:"avatar,omitempty"`
+	Banner      *ImagemPerfil `json:"banner,omitempty"`
+	Bio         string        `json:"bio,omitempty"`
+	Localizacao string        `json:"localizacao,omitempty"`
+	Site        string        `json:"site,omitempty"`
+	// DataNascimento está no formato AAAA-MM-DD e só aparece para outros usuários quando DataNascimentoPublica
+	DataNascimento        *string `json:"dataNascimento,omitempty"`
+	DataNascimentoPublica bool    `json:"dataNascimentoPublica,omitempty"`
+	// PublicacaoFixadaId é a publicação exibida no topo do perfil
+	PublicacaoFixadaId *uint64 `json:"publicacaoFixadaId,omitempty"`
+	// MensagensApenasSeguidos restringe as mensagens diretas recebidas a quem o usuário segue
+	MensagensApenasSeguidos bool `json:"mensagensApenasSeguidos,omitempty"`
+	// Privado faz os follows virarem solicitações e mostra publicações e seguidores só a seguidores aceitos
+	Privado bool `json:"privado"`
+}
+
+// Limites dos campos de perfil
+const (
+	tamanhoMaximoBio         = 160
+	tamanhoMaximoLocalizacao = 50
+	tamanhoMaximoSite        = 100
+	formatoDataNascimento    = "2006-01-02"
+)
+
+// OcultarPrivados remove os dados que só o próprio usuário pode ver
+func (usuario *Usuario) OcultarPrivados(visitanteId uint64) {
+	if visitanteId != usuario.Id && !usuario.DataNascimentoPublica {
+		usuario.DataNascimento = nil
+	}
+	if visitanteId != usuario.Id {
+		usuario.MensagensApenasSeguidos = false
+	}
 }
 
 func (usuario *Usuario) Preparar(cadastro bool) error {
@@ -51,13 +86,58 @@ func (usuario *Usuario) validar(cadastro bool) error {
 			return errors.New("Senha do usuário é obrigatória")
 		}
 	}
+
+	return usuario.validarPerfil()
+}
+
+func (usuario *Usuario) validarPerfil() error {
+	if utf8.RuneCountInString(strings.TrimSpace(usuario.Bio)) > tamanhoMaximoBio {
+		return fmt.Errorf("A bio deve ter no máximo %d caracteres", tamanhoMaximoBio)
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(usuario.Localizacao)) > tamanhoMaximoLocalizacao {
+		return fmt.Errorf("A localização deve ter no máximo %d caracteres", tamanhoMaximoLocalizacao)
+	}
+
+	if site := strings.TrimSpace(usuario.Site); site != "" {
+		if len(normalizarSite(site)) > tamanhoMaximoSite {
+			return fmt.Errorf("O site deve ter no máximo %d caracteres", tamanhoMaximoSite)
+		}
+		endereco, erro := url.Parse(normalizarSite(site))
+		if erro != nil || (endereco.Scheme != "http" && endereco.Scheme != "https") ||
+			endereco.Hostname() == "" || !strings.Contains(endereco.Hostname(), ".") {
+			return errors.New("Site inválido")
+		}
+	}
+
+	if usuario.DataNascimento != nil {
+		data, erro := time.Parse(formatoDataNascimento, *usuario.DataNascimento)
+		if erro != nil {
+			return errors.New("Data de nascimento inválida. Use o formato AAAA-MM-DD")
+		}
+		if data.After(time.Now()) {
+			return errors.New("A data de nascimento não pode estar no futuro")
+		}
+	}
 	return nil
 }
 
+// normalizarSite aceita o site sem o esquema, assumindo https
+func normalizarSite(site string) string {
+	if !strings.Contains(site, "://") {
+		return "https://" + site
+	}
+	return site
+}
+
 func (usuario *Usuario) formatar(cadastro bool) error {
 	usuario.Nome = strings.TrimSpace(usuario.Nome)
 	usuario.Nick = strings.TrimSpace(usuario.Nick)
 	usuario.Email = strings.TrimSpace(usuario.Email)
+	usuario.Bio = strings.TrimSpace(usuario.Bio)
+	usuario.Localizacao = strings.TrimSpace(usuario.Localizacao)
+	if site := strings.TrimSpace(usuario.Site); site != "" {
+		usuario.Site = normalizarSite(site)
+	}
 
 	if cadastro {
 		senhaComHash, erro := security.Hash(usuario.Senha)
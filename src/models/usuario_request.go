@@ -12,3 +12,63 @@ type UpdateUsuarioRequest struct {
 	Nick  string `json:"nick,omitempty"`
 	Email string `json:"email,omitempty"`
 }
+
+// AtualizarPerfilRequest é a atualização parcial do perfil: só os campos enviados são alterados.
+// PublicacaoFixadaId 0 desafixa a publicação
+type AtualizarPerfilRequest struct {
+	Nome                    *string `json:"nome,omitempty"`
+	Nick                    *string `json:"nick,omitempty"`
+	Email                   *string `json:"email,omitempty"`
+	Bio                     *string `json:"bio,omitempty"`
+	Localizacao             *string `json:"localizacao,omitempty"`
+	Site                    *string `json:"site,omitempty"`
+	DataNascimento          *string `json:"dataNascimento,omitempty"`
+	DataNascimentoPublica   *bool   `json:"dataNascimentoPublica,omitempty"`
+	PublicacaoFixadaId      *uint64 `json:"publicacaoFixadaId,omitempty"`
+	MensagensApenasSeguidos *bool   `json:"mensagensApenasSeguidos,omitempty"`
+	Privado                 *bool   `json:"privado,omitempty"`
+}
+
+// Aplicar copia para o usuário os campos presentes na requisição.
+// DataNascimento vazia remove a data
+func (request AtualizarPerfilRequest) Aplicar(usuario *Usuario) {
+	if request.Nome != nil {
+		usuario.Nome = *request.Nome
+	}
+	if request.Nick != nil {
+		usuario.Nick = *request.Nick
+	}
+	if request.Email != nil {
+		usuario.Email = *request.Email
+	}
+	if request.Bio != nil {
+		usuario.Bio = *request.Bio
+	}
+	if request.Localizacao != nil {
+		usuario.Localizacao = *request.Localizacao
+	}
+	if request.Site != nil {
+		usuario.Site = *request.Site
+	}
+	if request.DataNascimento != nil {
+		usuario.DataNascimento = request.DataNascimento
+		if *request.DataNascimento == "" {
+			usuario.DataNascimento = nil
+		}
+	}
+	if request.DataNascimentoPublica != nil {
+		usuario.DataNascimentoPublica = *request.DataNascimentoPublica
+	}
+	if request.PublicacaoFixadaId != nil {
+		usuario.PublicacaoFixadaId = request.PublicacaoFixadaId
+		if *request.PublicacaoFixadaId == 0 {
+			usuario.PublicacaoFixadaId = nil
+		}
+	}
+	if request.MensagensApenasSeguidos != nil {
+		usuario.MensagensApenasSeguidos = *request.MensagensApenasSeguidos
+	}
+	if request.Privado != nil {
+		usuario.Privado = *request.Privado
+	}
+}
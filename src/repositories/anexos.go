@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"api/src/midia"
+	"api/src/models"
+	"database/sql"
+)
+
+type Anexos struct {
+	db *sql.DB
+}
+
+// Cria instancia de anexos com banco para realizar as funções
+// Pode ser passado qualquer banco
+func NewAnexosRepo(db *sql.DB) *Anexos {
+	return &Anexos{db}
+}
+
+func (repository Anexos) Criar(anexo models.Anexo) (uint64, error) {
+	statement, erro := repository.db.Prepare(`insert into anexos
+		(publicacao_id, chave, chave_miniatura, tipo, largura, altura, tamanho) values (?,?,?,?,?,?,?)`)
+
+	if erro != nil {
+		return 0, erro
+	}
+	defer statement.Close()
+
+	insercao, erro := statement.Exec(anexo.PublicacaoId, anexo.Chave, anexo.ChaveMiniatura,
+		anexo.Tipo, anexo.Largura, anexo.Altura, anexo.Tamanho)
+
+	if erro != nil {
+		return 0, erro
+	}
+
+	idInserido, erro := insercao.LastInsertId()
+	if erro != nil {
+		return 0, erro
+	}
+
+	return uint64(idInserido), nil
+}
+
+func (repository Anexos) BuscarPorId(anexoId uint64) (models.Anexo, error) {
+	anexo, erro := scanAnexo(repository.db.QueryRow(selectAnexo+" where id = ?", anexoId))
+	if erro != nil {
+		return anexo, erro
+	}
+	return assinarAnexo(anexo), nil
+}
+
+func (repository Anexos) BuscarPorPublicacao(publicacaoId uint64) ([]models.Anexo, error) {
+	return buscarListaAnexos(repository.db, selectAnexo+" where publicacao_id = ? order by id", publicacaoId)
+}
+
+// Quantidade retorna quantos anexos a publicação tem
+func (repository Anexos) Quantidade(publicacaoId uint64) (int, error) {
+	var quantidade int
+	erro := repository.db.QueryRow("select count(*) from anexos where publicacao_id = ?", publicacaoId).Scan(&quantidade)
+	return quantidade, erro
+}
+
+func (repository Anexos) Deletar(anexoId uint64) error {
+	statement, erro := repository.db.Prepare("delete from anexos where id = ?")
+
+	if erro != nil {
+		return erro
+	}
+	defer statement.Close()
+
+	if _, erro := statement.Exec(anexoId); erro != nil {
+		return erro
+	}
+	return nil
+}
+
+const selectAnexo = `select id, publicacao_id, chave, chave_miniatura, tipo, largura, altura, tamanho, criadoEm from anexos`
+
+func scanAnexo(linha scanner) (models.Anexo, error) {
+	var anexo models.Anexo
+	erro := linha.Scan(&anexo.Id, &anexo.PublicacaoId, &anexo.Chave, &anexo.ChaveMiniatura,
+		&anexo.Tipo, &anexo.Largura, &anexo.Altura, &anexo.Tamanho, &anexo.CriadoEm)
+	return anexo, erro
+}
+
+func buscarListaAnexos(db *sql.DB, query string, args ...any) ([]models.Anexo, error) {
+	linhas, erro := db.Query(query, args...)
+	if erro != nil {
+		return nil, erro
+	}
+
+	defer linhas.Close()
+
+	anexos := []models.Anexo{}
+
+	for linhas.Next() {
+		anexo, erro := scanAnexo(linhas)
+		if erro != nil {
+			return nil, erro
+		}
+		anexos = append(anexos, assinarAnexo(anexo))
+	}
+
+	return anexos, linhas.Err()
+}
+
+// assinarAnexo preenche as URLs assinadas a partir das chaves do armazenamento
+func assinarAnexo(anexo models.Anexo) models.Anexo {
+	anexo.Url = midia.URLAssinada(anexo.Chave)
+	anexo.UrlMiniatura = midia.URLAssinada(anexo.ChaveMiniatura)
+	return anexo
+}
+
+// carregarAnexos preenche os anexos de cada publicação com uma única consulta
+func carregarAnexos(db *sql.DB, publicacoes []models.Publicacao) error {
+	if len(publicacoes) == 0 {
+		return nil
+	}
+
+	ids := idsPublicacoes(publicacoes)
+	anexos, erro := buscarListaAnexos(db, selectAnexo+`
+		where publicacao_id in (`+placeholders(len(ids))+`) order by id`, ids...)
+	if erro != nil {
+		return erro
+	}
+
+	porPublicacao := make(map[uint64][]models.Anexo)
+	for _, anexo := range anexos {
+		porPublicacao[anexo.PublicacaoId] = append(porPublicacao[anexo.PublicacaoId], anexo)
+	}
+
+	for i := range publicacoes {
+		publicacoes[i].Anexos = porPublicacao[publicacoes[i].Id]
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"api/src/midia"
+	"api/src/models"
+	"database/sql"
+	"errors"
+)
+
+// ErrBloqueado é retornado quando a ação envolve dois usuários e um deles bloqueou o outro
+var ErrBloqueado = errors.New("Não é possível interagir com este usuário")
+
+// semBloqueio filtra as linhas em que o usuário da coluna bloqueou ou foi bloqueado pelo
+// visitante. Recebe o id do visitante duas vezes
+func semBloqueio(coluna string) string {
+	return `NOT EXISTS (SELECT 1 FROM bloqueios b
+	       WHERE (b.usuario_id = ? AND b.bloqueado_id = ` + coluna + `)
+	          OR (b.usuario_id = ` + coluna + ` AND b.bloqueado_id = ?))`
+}
+
+// semSilenciado filtra as linhas em que o usuário da coluna foi silenciado pelo visitante.
+// Recebe o id do visitante
+func semSilenciado(coluna string) string {
+	return `NOT EXISTS (SELECT 1 FROM silenciados si WHERE si.usuario_id = ? AND si.silenciado_id = ` + coluna + `)`
+}
+
+// bloqueados informa se um dos usuários bloqueou o outro
+func bloqueados(exec executor, usuarioId uint64, outroId uint64) (bool, error) {
+	linhas, erro := exec.Query(`select 1 from bloqueios
+		where (usuario_id = ? and bloqueado_id = ?) or (usuario_id = ? and bloqueado_id = ?)`,
+		usuarioId, outroId, outroId, usuarioId)
+	if erro != nil {
+		return false, erro
+	}
+	defer linhas.Close()
+
+	return linhas.Next(), linhas.Err()
+}
+
+type Bloqueios struct {
+	db *sql.DB
+}
+
+// Cria instancia de bloqueios com banco para realizar as funções
+func NewBloqueiosRepo(db *sql.DB) *Bloqueios {
+	return &Bloqueios{db}
+}
+
+// Bloquear registra o bloqueio e desfaz os follows e solicitações entre os dois usuários, nos dois sentidos.
+// Bloquear de novo não tem efeito
+func (repository Bloqueios) Bloquear(usuarioId uint64, bloqueadoId uint64) error {
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return erro
+	}
+	defer tx.Rollback()
+
+	if erro := alterarRelacao(tx, []uint64{usuarioId, bloqueadoId},
+		"insert ignore into bloqueios (usuario_id, bloqueado_id) values (?, ?)", usuarioId, bloqueadoId); erro != nil {
+		return erro
+	}
+
+	if _, erro := desfazerSeguir(tx, usuarioId, bloqueadoId); erro != nil {
+		return erro
+	}
+	if _, erro := desfazerSeguir(tx, bloqueadoId, usuarioId); erro != nil {
+		return erro
+	}
+
+	return tx.Commit()
+}
+
+// Desbloquear remove o bloqueio. Os follows desfeitos no bloqueio não voltam
+func (repository Bloqueios) Desbloquear(usuarioId uint64, bloqueadoId uint64) error {
+	return repository.alterar([]uint64{usuarioId, bloqueadoId},
+		"delete from bloqueios where usuario_id = ? and bloqueado_id = ?", usuarioId, bloqueadoId)
+}
+
+// BuscarBloqueados lista quem o usuário bloqueou, dos bloqueios mais recentes para os mais antigos
+func (repository Bloqueios) BuscarBloqueados(usuarioId uint64, paginacao models.Paginacao) ([]models.Usuario, error) {
+	return repository.buscarUsuarios(`select u.id, u.nome, u.nick, u.avatar from usuarios u
+		inner join bloqueios b on b.bloqueado_id = u.id
+		where b.usuario_id = ?
+		order by b.criadoEm desc, u.id desc
+		limit ? offset ?`, usuarioId, paginacao.Limite, paginacao.Offset())
+}
+
+// Silenciar esconde do feed do usuário as publicações e republicações do silenciado, sem que ele saiba.
+// Silenciar de novo não tem efeito
+func (repository Bloqueios) Silenciar(usuarioId uint64, silenciadoId uint64) error {
+	return repository.alterar([]uint64{usuarioId}, "insert ignore into silenciados (usuario_id, silenciado_id) values (?, ?)",
+		usuarioId, silenciadoId)
+}
+
+// Dessilenciar volta a mostrar no feed as publicações do silenciado
+func (repository Bloqueios) Dessilenciar(usuarioId uint64, silenciadoId uint64) error {
+	return repository.alterar([]uint64{usuarioId}, "delete from silenciados where usuario_id = ? and silenciado_id = ?",
+		usuarioId, silenciadoId)
+}
+
+// alterar executa a mudança de bloqueio ou silêncio em uma transação própria
+func (repository Bloqueios) alterar(avisar []uint64, query string, args ...any) error {
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return erro
+	}
+	defer tx.Rollback()
+
+	if erro := alterarRelacao(tx, avisar, query, args...); erro != nil {
+		return erro
+	}
+
+	return tx.Commit()
+}
+
+// alterarRelacao executa a mudança e, se ela mudou alguma coisa, avisa as conexões em tempo real
+// dos usuários em avisar, para que atualizem os autores que acompanham
+func alterarRelacao(exec executor, avisar []uint64, query string, args ...any) error {
+	resultado, erro := exec.Exec(query, args...)
+	if erro != nil {
+		return erro
+	}
+
+	if alteradas, erro := resultado.RowsAffected(); erro != nil || alteradas == 0 {
+		return erro
+	}
+
+	for _, usuarioId := range avisar {
+		if erro := registrarMudancaRelacao(exec, usuarioId); erro != nil {
+			return erro
+		}
+	}
+
+	return nil
+}
+
+// BuscarSilenciados lista quem o usuário silenciou, dos mais recentes para os mais antigos
+func (repository Bloqueios) BuscarSilenciados(usuarioId uint64, paginacao models.Paginacao) ([]models.Usuario, error) {
+	return repository.buscarUsuarios(`select u.id, u.nome, u.nick, u.avatar from usuarios u
+		inner join silenciados si on si.silenciado_id = u.id
+		where si.usuario_id = ?
+		order by si.criadoEm desc, u.id desc
+		limit ? offset ?`, usuarioId, paginacao.Limite, paginacao.Offset())
+}
+
+func (repository Bloqueios) buscarUsuarios(query string, args ...any) ([]models.Usuario, error) {
+	linhas, erro := repository.db.Query(query, args...)
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	usuarios := []models.Usuario{}
+	for linhas.Next() {
+		var usuario models.Usuario
+		var avatar sql.NullString
+		if erro := linhas.Scan(&usuario.Id, &usuario.Nome, &usuario.Nick, &avatar); erro != nil {
+			return nil, erro
+		}
+		usuario.Avatar = ImagemPerfil(avatar, midia.TamanhosAvatar)
+		usuarios = append(usuarios, usuario)
+	}
+
+	return usuarios, linhas.Err()
+}
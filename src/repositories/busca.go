@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"api/src/models"
+	"strings"
+)
+
+// filtrosBusca monta as condições dos filtros sobre a publicação p e os parâmetros delas
+func filtrosBusca(filtros models.FiltrosBusca) (string, []any) {
+	condicoes := []string{}
+	args := []any{}
+
+	if filtros.AutorId != nil {
+		condicoes = append(condicoes, "p.autor_id = ?")
+		args = append(args, *filtros.AutorId)
+	}
+	if filtros.Desde != nil {
+		condicoes = append(condicoes, "p.criadaEm >= ?")
+		args = append(args, *filtros.Desde)
+	}
+	if filtros.Ate != nil {
+		condicoes = append(condicoes, "p.criadaEm < ?")
+		args = append(args, *filtros.Ate)
+	}
+	if filtros.Tag != "" {
+		condicoes = append(condicoes, `EXISTS (SELECT 1 FROM publicacoes_tags pt
+	       INNER JOIN tags t ON t.id = pt.tag_id
+	       WHERE pt.publicacao_id = p.id AND t.nome = ?)`)
+		args = append(args, filtros.Tag)
+	}
+
+	if len(condicoes) == 0 {
+		return "", args
+	}
+	return " AND " + strings.Join(condicoes, " AND "), args
+}
+
+// BuscarTexto usa o índice FULLTEXT de título e conteúdo. A expressão segue o modo booleano do MySQL
+// e já vem montada pelo motor de busca. Só entram as publicações que o visitante pode ver
+func (repository Publicacoes) BuscarTexto(expressao string, filtros models.FiltrosBusca, visitanteId uint64, paginacao models.Paginacao) ([]models.Relevancia, error) {
+	condicoes, argsFiltros := filtrosBusca(filtros)
+
+	args := append([]any{expressao, expressao}, argsVisivel(visitanteId)...)
+	args = append(args, argsFiltros...)
+	args = append(args, paginacao.Limite, paginacao.Offset())
+
+	linhas, erro := repository.db.Query(`SELECT p.id, MATCH(p.titulo, p.conteudo) AGAINST (? IN BOOLEAN MODE) AS pontuacao
+	   FROM publicacoes p
+	   INNER JOIN usuarios u ON u.id = p.autor_id
+	   WHERE MATCH(p.titulo, p.conteudo) AGAINST (? IN BOOLEAN MODE)
+	     AND p.status = 'publicada' AND `+publicacaoVisivel+condicoes+`
+	   ORDER BY pontuacao DESC, p.id DESC
+	   LIMIT ? OFFSET ?`, args...)
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	resultados := []models.Relevancia{}
+	for linhas.Next() {
+		var resultado models.Relevancia
+		if erro := linhas.Scan(&resultado.PublicacaoId, &resultado.Pontuacao); erro != nil {
+			return nil, erro
+		}
+		resultados = append(resultados, resultado)
+	}
+
+	return resultados, linhas.Err()
+}
+
+// FiltrarBusca retorna, dentre as publicações informadas, as que passam pelos filtros
+// e que o visitante pode ver
+func (repository Publicacoes) FiltrarBusca(ids []uint64, filtros models.FiltrosBusca, visitanteId uint64) (map[uint64]bool, error) {
+	aceitas := make(map[uint64]bool)
+	if len(ids) == 0 {
+		return aceitas, nil
+	}
+
+	condicoes, argsFiltros := filtrosBusca(filtros)
+
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	args = append(args, argsVisivel(visitanteId)...)
+	args = append(args, argsFiltros...)
+
+	linhas, erro := repository.db.Query(`SELECT p.id FROM publicacoes p
+	   INNER JOIN usuarios u ON u.id = p.autor_id
+	   WHERE p.id IN (`+placeholders(len(ids))+`)
+	     AND p.status = 'publicada' AND `+publicacaoVisivel+condicoes, args...)
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	for linhas.Next() {
+		var id uint64
+		if erro := linhas.Scan(&id); erro != nil {
+			return nil, erro
+		}
+		aceitas[id] = true
+	}
+
+	return aceitas, linhas.Err()
+}
+
+// BuscarParaIndice retorna id, título e conteúdo de todas as publicações publicadas,
+// para montar um índice de busca fora do banco
+func (repository Publicacoes) BuscarParaIndice() ([]models.Publicacao, error) {
+	linhas, erro := repository.db.Query("select id, titulo, conteudo from publicacoes where status = 'publicada'")
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	publicacoes := []models.Publicacao{}
+	for linhas.Next() {
+		var publicacao models.Publicacao
+		if erro := linhas.Scan(&publicacao.Id, &publicacao.Titulo, &publicacao.Conteudo); erro != nil {
+			return nil, erro
+		}
+		publicacoes = append(publicacoes, publicacao)
+	}
+
+	return publicacoes, linhas.Err()
+}
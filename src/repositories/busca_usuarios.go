@@ -0,0 +1,221 @@
+package repositories
+
+import (
+	"api/src/midia"
+	"api/src/models"
+	"database/sql"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maximoCandidatosUsuarios limita quantos usuários a busca ordena antes de paginar
+	maximoCandidatosUsuarios = 500
+	// maximoAutocompletar limita as sugestões para @menções
+	maximoAutocompletar = 20
+)
+
+// Correspondências da busca de usuários, da melhor para a pior
+const (
+	correspondenciaNickExato = iota
+	correspondenciaPrefixoNick
+	correspondenciaPrefixoNome
+	correspondenciaTrecho
+	correspondenciaAproximada
+)
+
+// escaparLike escapa os curingas do LIKE, para que % e _ digitados sejam buscados literalmente
+func escaparLike(texto string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(texto)
+}
+
+// toleranciaErros é quantas letras erradas a busca aceita para o tamanho do termo
+func toleranciaErros(termo string) int {
+	switch tamanho := utf8.RuneCountInString(termo); {
+	case tamanho <= 3:
+		return 0
+	case tamanho <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// usuarioEncontrado é um candidato da busca com o que decide a posição dele
+type usuarioEncontrado struct {
+	usuario         models.Usuario
+	correspondencia int
+	distancia       int
+	sigo, meSegue   bool
+	seguidores      uint64
+}
+
+// BuscarUsuarios procura pelo começo ou por um trecho do nick e do nome, sem diferenciar maiúsculas
+// e acentos, e também por nicks e primeiros nomes com poucas letras erradas, desde que a primeira
+// esteja certa. O nick exato vem primeiro, depois quem o visitante segue, quem segue o visitante e
+// então a qualidade da correspondência. Quem tem bloqueio com o visitante não aparece
+func (repository Usuarios) BuscarUsuarios(termo string, visitanteId uint64, paginacao models.Paginacao) ([]models.Usuario, error) {
+	termo = strings.ToLower(termo)
+	tolerancia := toleranciaErros(termo)
+	tamanho := utf8.RuneCountInString(termo)
+	prefixo := escaparLike(termo) + "%"
+	trecho := "%" + escaparLike(termo) + "%"
+
+	// Sem tolerância, a condição da correspondência aproximada nunca é verdadeira
+	primeiraLetra := ""
+	if tolerancia > 0 {
+		_, tamanhoLetra := utf8.DecodeRuneInString(termo)
+		primeiraLetra = termo[:tamanhoLetra]
+	}
+
+	args := []any{termo, prefixo, prefixo, "% " + prefixo, trecho, trecho,
+		visitanteId, visitanteId,
+		trecho, trecho,
+		primeiraLetra, tamanho - tolerancia, tamanho + tolerancia,
+		primeiraLetra, tamanho - tolerancia, tamanho + tolerancia,
+		visitanteId, visitanteId,
+		maximoCandidatosUsuarios}
+
+	linhas, erro := repository.db.Query(`select u.id, u.nome, u.email, u.nick, u.criadoEm, u.avatar, u.banner,
+		       case when u.nick = ? then 0
+		            when u.nick like ? then 1
+		            when u.nome like ? or u.nome like ? then 2
+		            when u.nick like ? or u.nome like ? then 3
+		            else 4 end as correspondencia,
+		       exists (select 1 from seguidores s where s.usuario_id = u.id and s.seguidor_id = ?) as sigo,
+		       exists (select 1 from seguidores s where s.usuario_id = ? and s.seguidor_id = u.id) as meSegue,
+		       (select count(*) from seguidores s where s.usuario_id = u.id) as seguidores
+		from usuarios u
+		where (u.nick like ? or u.nome like ?
+		       or (left(u.nick, 1) = ? and char_length(u.nick) between ? and ?)
+		       or (left(u.nome, 1) = ? and char_length(substring_index(u.nome, ' ', 1)) between ? and ?))
+		  and `+semBloqueio("u.id")+`
+		order by correspondencia = 0 desc, sigo desc, meSegue desc, correspondencia, seguidores desc, u.nick, u.id
+		limit ?`, args...)
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	encontrados := []usuarioEncontrado{}
+	for linhas.Next() {
+		var encontrado usuarioEncontrado
+		var avatar, banner sql.NullString
+		if erro := linhas.Scan(&encontrado.usuario.Id, &encontrado.usuario.Nome, &encontrado.usuario.Email,
+			&encontrado.usuario.Nick, &encontrado.usuario.CriadoEm, &avatar, &banner,
+			&encontrado.correspondencia, &encontrado.sigo, &encontrado.meSegue, &encontrado.seguidores); erro != nil {
+			return nil, erro
+		}
+
+		if encontrado.correspondencia == correspondenciaAproximada {
+			encontrado.distancia = min(
+				distanciaEdicao(termo, strings.ToLower(encontrado.usuario.Nick)),
+				distanciaEdicao(termo, strings.ToLower(strings.SplitN(encontrado.usuario.Nome, " ", 2)[0])))
+			if encontrado.distancia > tolerancia {
+				continue
+			}
+		}
+
+		encontrado.usuario.Avatar = ImagemPerfil(avatar, midia.TamanhosAvatar)
+		encontrado.usuario.Banner = ImagemPerfil(banner, midia.TamanhosBanner)
+		encontrados = append(encontrados, encontrado)
+	}
+
+	if erro := linhas.Err(); erro != nil {
+		return nil, erro
+	}
+
+	sort.SliceStable(encontrados, func(i, j int) bool {
+		a, b := encontrados[i], encontrados[j]
+		if exatoA, exatoB := a.correspondencia == correspondenciaNickExato, b.correspondencia == correspondenciaNickExato; exatoA != exatoB {
+			return exatoA
+		}
+		if a.sigo != b.sigo {
+			return a.sigo
+		}
+		if a.meSegue != b.meSegue {
+			return a.meSegue
+		}
+		if a.correspondencia != b.correspondencia {
+			return a.correspondencia < b.correspondencia
+		}
+		if a.distancia != b.distancia {
+			return a.distancia < b.distancia
+		}
+		if a.seguidores != b.seguidores {
+			return a.seguidores > b.seguidores
+		}
+		if a.usuario.Nick != b.usuario.Nick {
+			return a.usuario.Nick < b.usuario.Nick
+		}
+		return a.usuario.Id < b.usuario.Id
+	})
+
+	usuarios := []models.Usuario{}
+	inicio := min(uint64(len(encontrados)), paginacao.Offset())
+	fim := min(uint64(len(encontrados)), inicio+paginacao.Limite)
+	for _, encontrado := range encontrados[inicio:fim] {
+		usuarios = append(usuarios, encontrado.usuario)
+	}
+
+	return usuarios, nil
+}
+
+// Autocompletar sugere usuários cujo nick ou nome começa com o prefixo, para completar @menções.
+// Usa só o índice do nick e o começo do nome, sem correspondência aproximada, para responder rápido
+func (repository Usuarios) Autocompletar(prefixo string, visitanteId uint64, limite uint64) ([]models.Usuario, error) {
+	padrao := escaparLike(strings.ToLower(prefixo)) + "%"
+	limite = min(limite, maximoAutocompletar)
+
+	linhas, erro := repository.db.Query(`select u.id, u.nome, u.nick, u.avatar from usuarios u
+		where (u.nick like ? or u.nome like ?) and `+semBloqueio("u.id")+`
+		order by u.nick = ? desc,
+		         exists (select 1 from seguidores s where s.usuario_id = u.id and s.seguidor_id = ?) desc,
+		         exists (select 1 from seguidores s where s.usuario_id = ? and s.seguidor_id = u.id) desc,
+		         u.nick like ? desc, char_length(u.nick), u.nick
+		limit ?`,
+		padrao, padrao, visitanteId, visitanteId, prefixo, visitanteId, visitanteId, padrao, limite)
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	usuarios := []models.Usuario{}
+	for linhas.Next() {
+		var usuario models.Usuario
+		var avatar sql.NullString
+		if erro := linhas.Scan(&usuario.Id, &usuario.Nome, &usuario.Nick, &avatar); erro != nil {
+			return nil, erro
+		}
+		usuario.Avatar = ImagemPerfil(avatar, midia.TamanhosAvatar)
+		usuarios = append(usuarios, usuario)
+	}
+
+	return usuarios, linhas.Err()
+}
+
+// distanciaEdicao é a distância de Levenshtein: quantas letras precisam ser trocadas, incluídas
+// ou removidas para ir de um texto ao outro
+func distanciaEdicao(a, b string) int {
+	origem, destino := []rune(a), []rune(b)
+	anterior := make([]int, len(destino)+1)
+	atual := make([]int, len(destino)+1)
+	for j := range anterior {
+		anterior[j] = j
+	}
+
+	for i := 1; i <= len(origem); i++ {
+		atual[0] = i
+		for j := 1; j <= len(destino); j++ {
+			custo := 1
+			if origem[i-1] == destino[j-1] {
+				custo = 0
+			}
+			atual[j] = min(anterior[j]+1, atual[j-1]+1, anterior[j-1]+custo)
+		}
+		anterior, atual = atual, anterior
+	}
+
+	return anterior[len(destino)]
+}
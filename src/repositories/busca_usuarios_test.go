@@ -0,0 +1,68 @@
+package repositories
+
+import "testing"
+
+func TestDistanciaEdicao(t *testing.T) {
+	casos := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"gato", "gato", 0},
+		{"gato", "rato", 1},
+		{"kitten", "sitting", 3},
+		{"ana", "naa", 2},
+		{"joão", "joao", 1},
+		{"joão", "joãos", 1},
+	}
+
+	for _, caso := range casos {
+		if got := distanciaEdicao(caso.a, caso.b); got != caso.want {
+			t.Errorf("distanciaEdicao(%q, %q) = %d, want %d", caso.a, caso.b, got, caso.want)
+		}
+		if got := distanciaEdicao(caso.b, caso.a); got != caso.want {
+			t.Errorf("distanciaEdicao(%q, %q) = %d, want %d", caso.b, caso.a, got, caso.want)
+		}
+	}
+}
+
+func TestEscaparLike(t *testing.T) {
+	casos := []struct {
+		texto, want string
+	}{
+		{"ana", "ana"},
+		{"100%", `100\%`},
+		{"ana_bia", `ana\_bia`},
+		{`c:\temp`, `c:\\temp`},
+		{`%_\`, `\%\_\\`},
+	}
+
+	for _, caso := range casos {
+		if got := escaparLike(caso.texto); got != caso.want {
+			t.Errorf("escaparLike(%q) = %q, want %q", caso.texto, got, caso.want)
+		}
+	}
+}
+
+func TestToleranciaErros(t *testing.T) {
+	casos := []struct {
+		termo string
+		want  int
+	}{
+		{"", 0},
+		{"ana", 0},
+		{"joão", 1},
+		{"ação", 1},
+		{"marcos", 1},
+		{"fernand", 2},
+		{"fernanda", 2},
+	}
+
+	for _, caso := range casos {
+		if got := toleranciaErros(caso.termo); got != caso.want {
+			t.Errorf("toleranciaErros(%q) = %d, want %d", caso.termo, got, caso.want)
+		}
+	}
+}
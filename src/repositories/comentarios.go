@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"api/src/midia"
+	"api/src/models"
+	"database/sql"
+)
+
+type Comentarios struct {
+	db *sql.DB
+}
+
+// Cria instancia de comentarios com banco para realizar as funções
+// Pode ser passado qualquer banco
+func NewComentariosRepo(db *sql.DB) *Comentarios {
+	return &Comentarios{db}
+}
+
+func (repository Comentarios) Criar(comentario models.Comentario) (uint64, error) {
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return 0, erro
+	}
+	defer tx.Rollback()
+
+	insercao, erro := tx.Exec("insert into comentarios (publicacao_id, autor_id, conteudo) values (?,?,?)",
+		comentario.PublicacaoId, comentario.AutorId, comentario.Conteudo)
+
+	if erro != nil {
+		return 0, erro
+	}
+
+	idInserido, erro := insercao.LastInsertId()
+	if erro != nil {
+		return 0, erro
+	}
+
+	if erro := notificarAutor(tx, models.NotificacaoComentario, comentario.PublicacaoId, comentario.AutorId); erro != nil {
+		return 0, erro
+	}
+
+	return uint64(idInserido), tx.Commit()
+}
+
+func (repository Comentarios) BuscarPorId(id uint64) (models.Comentario, error) {
+	comentario := models.Comentario{}
+	var autorAvatar sql.NullString
+	erro := repository.db.QueryRow(`SELECT c.id, c.publicacao_id, c.autor_id, u.nick, u.avatar, c.conteudo, c.criadoEm, c.atualizadoEm
+	   FROM comentarios c
+	   INNER JOIN usuarios u ON u.id = c.autor_id
+	   WHERE c.id = ?`, id).Scan(&comentario.Id, &comentario.PublicacaoId, &comentario.AutorId,
+		&comentario.AutorNick, &autorAvatar, &comentario.Conteudo, &comentario.CriadoEm, &comentario.AtualizadoEm)
+
+	comentario.AutorAvatar = ImagemPerfil(autorAvatar, midia.TamanhosAvatar)
+	return comentario, erro
+}
+
+// BuscarPorPublicacao retorna os comentários de uma publicação, do mais antigo para o mais novo
+func (repository Comentarios) BuscarPorPublicacao(publicacaoId uint64, paginacao models.Paginacao) ([]models.Comentario, error) {
+	linhas, erro := repository.db.Query(`SELECT c.id, c.publicacao_id, c.autor_id, u.nick, u.avatar, c.conteudo, c.criadoEm, c.atualizadoEm
+	   FROM comentarios c
+	   INNER JOIN usuarios u ON u.id = c.autor_id
+	   WHERE c.publicacao_id = ?
+	   ORDER BY c.id
+	   LIMIT ? OFFSET ?`, publicacaoId, paginacao.Limite, paginacao.Offset())
+	if erro != nil {
+		return nil, erro
+	}
+
+	defer linhas.Close()
+
+	comentarios := []models.Comentario{}
+
+	for linhas.Next() {
+		var comentario models.Comentario
+		var autorAvatar sql.NullString
+		if erro := linhas.Scan(&comentario.Id, &comentario.PublicacaoId, &comentario.AutorId,
+			&comentario.AutorNick, &autorAvatar, &comentario.Conteudo, &comentario.CriadoEm, &comentario.AtualizadoEm); erro != nil {
+			return nil, erro
+		}
+		comentario.AutorAvatar = ImagemPerfil(autorAvatar, midia.TamanhosAvatar)
+		comentarios = append(comentarios, comentario)
+	}
+
+	return comentarios, linhas.Err()
+}
+
+func (repository Comentarios) Atualizar(comentario models.Comentario) error {
+	statement, erro := repository.db.Prepare("update comentarios set conteudo = ?, atualizadoEm = current_timestamp() where id = ?")
+
+	if erro != nil {
+		return erro
+	}
+	defer statement.Close()
+
+	if _, erro := statement.Exec(comentario.Conteudo, comentario.Id); erro != nil {
+		return erro
+	}
+	return nil
+}
+
+func (repository Comentarios) Deletar(comentarioId uint64) error {
+	statement, erro := repository.db.Prepare("delete from comentarios where id = ?")
+
+	if erro != nil {
+		return erro
+	}
+	defer statement.Close()
+
+	if _, erro := statement.Exec(comentarioId); erro != nil {
+		return erro
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package repositories
+
+import (
+	"api/src/models"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+type Eventos struct {
+	db *sql.DB
+}
+
+// Cria instancia de eventos com banco para realizar as funções
+func NewEventosRepo(db *sql.DB) *Eventos {
+	return &Eventos{db}
+}
+
+// registrarEvento grava o evento na mesma transação da mudança que ele descreve, então só é
+// entregue se a mudança for confirmada. A tabela funciona como fila compartilhada entre as
+// instâncias da API: cada uma lê os eventos novos e entrega aos seus clientes conectados
+func registrarEvento(exec executor, tipo string, usuarioId *uint64, autorId *uint64, dados any) error {
+	conteudo, erro := json.Marshal(dados)
+	if erro != nil {
+		return erro
+	}
+
+	_, erro = exec.Exec("insert into eventos (tipo, usuario_id, autor_id, dados) values (?, ?, ?, ?)",
+		tipo, usuarioId, autorId, conteudo)
+	return erro
+}
+
+// registrarMudancaReacoes avisa os seguidores do autor que a contagem de reações mudou
+func registrarMudancaReacoes(exec executor, publicacaoId uint64) error {
+	autorId, encontrada, erro := autorPublicada(exec, publicacaoId)
+	if erro != nil || !encontrada {
+		return erro
+	}
+
+	return registrarEvento(exec, models.EventoReacoes, nil, &autorId, models.DadosEventoReacoes{PublicacaoId: publicacaoId})
+}
+
+// registrarMudancaRelacao avisa as conexões do usuário que os autores que ele acompanha mudaram
+func registrarMudancaRelacao(exec executor, usuarioId uint64) error {
+	return registrarEvento(exec, models.EventoRelacao, &usuarioId, nil, struct{}{})
+}
+
+// UltimoId retorna o id do evento mais recente, ou 0 se não houver nenhum
+func (repository Eventos) UltimoId() (uint64, error) {
+	var id uint64
+	erro := repository.db.QueryRow("select coalesce(max(id), 0) from eventos").Scan(&id)
+	return id, erro
+}
+
+// BuscarDesde retorna até limite eventos posteriores a ultimoId, em ordem, com os dados
+// atualizados: a publicação completa, a contagem de reações atual e as notificações não lidas.
+// Eventos cuja publicação já foi removida são descartados, por isso lidos traz os ids de
+// todos os eventos lidos, entregues ou não
+func (repository Eventos) BuscarDesde(ultimoId uint64, limite int) (eventos []models.Evento, lidos []uint64, erro error) {
+	return repository.buscar(`select id, tipo, usuario_id, autor_id, dados, criadoEm
+		from eventos where id > ? order by id limit ?`, ultimoId, limite)
+}
+
+// BuscarIds é BuscarDesde para ids específicos. Serve para reler ids que ficaram para trás
+// porque a transação que os criou ainda não tinha sido confirmada
+func (repository Eventos) BuscarIds(ids []uint64) (eventos []models.Evento, lidos []uint64, erro error) {
+	if len(ids) == 0 {
+		return nil, nil, nil
+	}
+
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	return repository.buscar(`select id, tipo, usuario_id, autor_id, dados, criadoEm
+		from eventos where id in (`+placeholders(len(ids))+`) order by id`, args...)
+}
+
+func (repository Eventos) buscar(query string, args ...any) ([]models.Evento, []uint64, error) {
+	linhas, erro := repository.db.Query(query, args...)
+	if erro != nil {
+		return nil, nil, erro
+	}
+	defer linhas.Close()
+
+	type eventoGravado struct {
+		evento models.Evento
+		dados  []byte
+	}
+	gravados := []eventoGravado{}
+	lidos := []uint64{}
+	for linhas.Next() {
+		var gravado eventoGravado
+		if erro := linhas.Scan(&gravado.evento.Id, &gravado.evento.Tipo, &gravado.evento.UsuarioId,
+			&gravado.evento.AutorId, &gravado.dados, &gravado.evento.CriadoEm); erro != nil {
+			return nil, nil, erro
+		}
+		gravados = append(gravados, gravado)
+		lidos = append(lidos, gravado.evento.Id)
+	}
+
+	if erro := linhas.Err(); erro != nil {
+		return nil, nil, erro
+	}
+	linhas.Close()
+
+	eventos := make([]models.Evento, 0, len(gravados))
+	for _, gravado := range gravados {
+		evento, entregar, erro := repository.completar(gravado.evento, gravado.dados)
+		if erro != nil {
+			return nil, nil, erro
+		}
+		if entregar {
+			eventos = append(eventos, evento)
+		}
+	}
+
+	return eventos, lidos, nil
+}
+
+// completar troca os dados gravados pelos que o cliente recebe
+func (repository Eventos) completar(evento models.Evento, dados []byte) (models.Evento, bool, error) {
+	switch evento.Tipo {
+	case models.EventoPublicacao:
+		var gravado models.DadosEventoPublicacao
+		if erro := json.Unmarshal(dados, &gravado); erro != nil {
+			return evento, false, erro
+		}
+
+		// O evento vai para todos os seguidores de AutorId, então só leva o que é aberto a todos eles:
+		// publicações para mencionados ficam de fora, e uma republicação só sai se a original for
+		// aberta a qualquer um. Pelo mesmo motivo, a citação embutida é a que o visitante 0 veria
+		publicacoes, erro := buscarListaPublicacoes(repository.db, 0, selectPublicacao+`
+		   WHERE p.id = ? AND p.status = 'publicada'`, gravado.PublicacaoId)
+		if erro != nil {
+			return evento, false, erro
+		}
+		if len(publicacoes) == 0 || publicacoes[0].Visibilidade == models.VisibilidadeMencionados {
+			return evento, false, nil
+		}
+		publicacao := publicacoes[0]
+
+		if gravado.RepublicadaPor != nil {
+			aberta, erro := NewPublicacoesRepo(repository.db).VisivelPara(publicacao.Id, 0)
+			if erro != nil || !aberta {
+				return evento, false, erro
+			}
+		}
+
+		if gravado.RepublicadaPor != nil {
+			publicacao.RepublicadaPor = &models.Republicacao{UsuarioId: *gravado.RepublicadaPor, CriadaEm: evento.CriadoEm}
+		}
+		evento.Dados = publicacao
+
+	case models.EventoReacoes:
+		var gravado models.DadosEventoReacoes
+		if erro := json.Unmarshal(dados, &gravado); erro != nil {
+			return evento, false, erro
+		}
+
+		publicacoes := []models.Publicacao{{Id: gravado.PublicacaoId}}
+		if erro := carregarReacoes(repository.db, publicacoes); erro != nil {
+			return evento, false, erro
+		}
+		gravado.Curtidas = publicacoes[0].Curtidas
+		gravado.Reacoes = publicacoes[0].Reacoes
+		evento.Dados = gravado
+
+	case models.EventoNotificacao:
+		var gravado models.DadosEventoNotificacao
+		if erro := json.Unmarshal(dados, &gravado); erro != nil {
+			return evento, false, erro
+		}
+
+		contagem, erro := NewNotificacoesRepo(repository.db).ContarNaoLidas(*evento.UsuarioId)
+		if erro != nil {
+			return evento, false, erro
+		}
+		gravado.NaoLidas = contagem
+		evento.Dados = gravado
+
+	default:
+		evento.Dados = json.RawMessage(dados)
+	}
+
+	return evento, true, nil
+}
+
+// RemoverAntigos apaga os eventos criados antes do momento informado, menos o mais recente, que
+// serve de referência para saber se um cliente perdeu eventos. Clientes que voltarem depois
+// disso recebem EventoReiniciar
+func (repository Eventos) RemoverAntigos(antes time.Time) error {
+	_, erro := repository.db.Exec(`delete from eventos
+		where criadoEm < ? and id < (select id from (select max(id) as id from eventos) ultimo)`, antes)
+	return erro
+}
+
+// PrimeiroId retorna o id do evento mais antigo ainda guardado, ou 0 se não houver nenhum
+func (repository Eventos) PrimeiroId() (uint64, error) {
+	var id uint64
+	erro := repository.db.QueryRow("select coalesce(min(id), 0) from eventos").Scan(&id)
+	return id, erro
+}
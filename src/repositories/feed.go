@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"api/src/models"
+	"math"
+	"time"
+)
+
+// janelaAfinidade é o período das reações e comentários que contam como interação com um autor
+const janelaAfinidade = 30 * 24 * time.Hour
+
+// BuscarFeedRelevancia monta o feed por relevância: publicações de quem o usuário segue e de quem
+// essas pessoas seguem, da janela até a referência. Cada uma vale 1 no momento em que foi publicada
+// e perde metade a cada meia-vida; o engajamento e a afinidade com o autor somam ao 1 pelo logaritmo,
+// e as de segundo grau são multiplicadas pelo peso próprio. Tudo é calculado como estava na referência,
+// então repetir a mesma referência mantém a ordem entre as páginas. Republicações ficam de fora
+func (repository Publicacoes) BuscarFeedRelevancia(usuarioId uint64, referencia time.Time, pesos models.PesosFeed, paginacao models.Paginacao) ([]models.Publicacao, error) {
+	inicioAfinidade := referencia.Add(-janelaAfinidade)
+
+	args := []any{usuarioId, inicioAfinidade, referencia, usuarioId, inicioAfinidade, referencia,
+		referencia.Add(-pesos.Janela), referencia, usuarioId,
+		usuarioId, usuarioId}
+	args = append(args, argsVisivel(usuarioId)...)
+	args = append(args, usuarioId,
+		-math.Ln2/pesos.MeiaVida.Seconds(), referencia,
+		pesos.Engajamento, referencia, referencia,
+		pesos.Afinidade,
+		usuarioId, pesos.SegundoGrau,
+		paginacao.Limite, paginacao.Offset())
+
+	return buscarListaPublicacoes(repository.db, usuarioId, selectPublicacao+`
+	   LEFT JOIN (SELECT pa.autor_id, COUNT(*) AS interacoes
+	              FROM (SELECT publicacao_id FROM reacoes WHERE usuario_id = ? AND criadaEm > ? AND criadaEm <= ?
+	                    UNION ALL
+	                    SELECT publicacao_id FROM comentarios WHERE autor_id = ? AND criadoEm > ? AND criadoEm <= ?) i
+	              INNER JOIN publicacoes pa ON pa.id = i.publicacao_id
+	              GROUP BY pa.autor_id) af ON af.autor_id = p.autor_id
+	   WHERE p.status = 'publicada' AND p.criadaEm > ? AND p.criadaEm <= ? AND p.autor_id <> ?
+	     AND p.autor_id IN (SELECT s.usuario_id FROM seguidores s WHERE s.seguidor_id = ?
+	                        UNION
+	                        SELECT s2.usuario_id FROM seguidores s1
+	                        INNER JOIN seguidores s2 ON s2.seguidor_id = s1.usuario_id
+	                        WHERE s1.seguidor_id = ?)
+	     AND `+publicacaoVisivel+` AND `+semSilenciado("p.autor_id")+`
+	   ORDER BY EXP(? * TIMESTAMPDIFF(SECOND, p.criadaEm, ?))
+	            * (1 + ? * LN(1 + (SELECT COUNT(*) FROM reacoes re WHERE re.publicacao_id = p.id AND re.criadaEm <= ?)
+	                             + (SELECT COUNT(*) FROM comentarios cm WHERE cm.publicacao_id = p.id AND cm.criadoEm <= ?))
+	                 + ? * LN(1 + COALESCE(af.interacoes, 0)))
+	            * IF(EXISTS (SELECT 1 FROM seguidores s WHERE s.usuario_id = p.autor_id AND s.seguidor_id = ?), 1, ?) DESC,
+	            p.id DESC
+	   LIMIT ? OFFSET ?`, args...)
+}
@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"api/src/models"
+	"database/sql"
+	"strings"
+)
+
+// salvarMencoes substitui as menções da publicação. Os nicks são resolvidos agora e a menção
+// guarda o id do usuário, então trocar de nick depois não quebra menções antigas.
+// Nicks que não existem e usuários com bloqueio com o autor são ignorados
+func salvarMencoes(exec executor, publicacaoId uint64, mencoes []models.Mencao) error {
+	if _, erro := exec.Exec("delete from mencoes where publicacao_id = ?", publicacaoId); erro != nil {
+		return erro
+	}
+
+	if len(mencoes) == 0 {
+		return nil
+	}
+
+	nicks := make([]any, 0, len(mencoes))
+	for _, mencao := range mencoes {
+		nicks = append(nicks, mencao.Nick)
+	}
+
+	linhas, erro := exec.Query(`select u.id, u.nick from usuarios u
+		inner join publicacoes p on p.id = ?
+		where u.nick in (`+placeholders(len(nicks))+`)
+		  and not exists (select 1 from bloqueios b
+		      where (b.usuario_id = u.id and b.bloqueado_id = p.autor_id)
+		         or (b.usuario_id = p.autor_id and b.bloqueado_id = u.id))`, append([]any{publicacaoId}, nicks...)...)
+	if erro != nil {
+		return erro
+	}
+	defer linhas.Close()
+
+	// A comparação do banco ignora maiúsculas, então a do mapa também
+	usuarios := make(map[string]uint64)
+	for linhas.Next() {
+		var id uint64
+		var nick string
+		if erro := linhas.Scan(&id, &nick); erro != nil {
+			return erro
+		}
+		usuarios[strings.ToLower(nick)] = id
+	}
+	if erro := linhas.Err(); erro != nil {
+		return erro
+	}
+	linhas.Close()
+
+	valores := []string{}
+	args := []any{}
+	for _, mencao := range mencoes {
+		usuarioId, existe := usuarios[strings.ToLower(mencao.Nick)]
+		if !existe {
+			continue
+		}
+		valores = append(valores, "(?, ?, ?, ?)")
+		args = append(args, publicacaoId, usuarioId, mencao.Inicio, mencao.Fim)
+	}
+
+	if len(valores) == 0 {
+		return nil
+	}
+
+	_, erro = exec.Exec("insert into mencoes (publicacao_id, usuario_id, inicio, fim) values "+strings.Join(valores, ","), args...)
+	return erro
+}
+
+// notificarMencoes notifica cada usuário mencionado nas publicações, desde que elas já estejam
+// publicadas e o usuário ainda não tenha sido avisado. Assim rascunhos não notificam e editar
+// uma publicação só avisa quem foi mencionado pela primeira vez. Quem não pode ver a publicação,
+// como um não seguidor mencionado numa publicação para seguidores, não é avisado
+func notificarMencoes(exec executor, publicacaoIds ...any) error {
+	if len(publicacaoIds) == 0 {
+		return nil
+	}
+
+	linhas, erro := exec.Query(`select distinct m.usuario_id, p.autor_id, p.id
+		from mencoes m
+		inner join publicacoes p on p.id = m.publicacao_id
+		inner join usuarios u on u.id = p.autor_id
+		where p.id in (`+placeholders(len(publicacaoIds))+`) and p.status = 'publicada'
+		  and (p.visibilidade = 'mencionados' or (p.visibilidade = 'publica' and not u.privado)
+		       or exists (select 1 from seguidores sv where sv.usuario_id = p.autor_id and sv.seguidor_id = m.usuario_id))
+		  and not exists (select 1 from notificacoes n
+		                  where n.tipo = ? and n.publicacao_id = p.id and n.usuario_id = m.usuario_id)`,
+		append(publicacaoIds, models.NotificacaoMencao)...)
+	if erro != nil {
+		return erro
+	}
+
+	type mencaoPendente struct{ usuarioId, autorId, publicacaoId uint64 }
+	pendentes := []mencaoPendente{}
+	for linhas.Next() {
+		var pendente mencaoPendente
+		if erro := linhas.Scan(&pendente.usuarioId, &pendente.autorId, &pendente.publicacaoId); erro != nil {
+			linhas.Close()
+			return erro
+		}
+		pendentes = append(pendentes, pendente)
+	}
+	linhas.Close()
+	if erro := linhas.Err(); erro != nil {
+		return erro
+	}
+
+	for _, pendente := range pendentes {
+		if erro := notificar(exec, models.NotificacaoMencao, pendente.usuarioId, pendente.autorId, &pendente.publicacaoId); erro != nil {
+			return erro
+		}
+	}
+	return nil
+}
+
+// BuscarMencoes retorna as menções já resolvidas de uma publicação
+func (repository Publicacoes) BuscarMencoes(publicacaoId uint64) ([]models.Mencao, error) {
+	publicacoes := []models.Publicacao{{Id: publicacaoId}}
+	if erro := carregarMencoes(repository.db, publicacoes); erro != nil {
+		return nil, erro
+	}
+	return publicacoes[0].Mencoes, nil
+}
+
+// carregarMencoes preenche as menções de cada publicação com uma única consulta,
+// trazendo o nick atual de cada usuário mencionado
+func carregarMencoes(db *sql.DB, publicacoes []models.Publicacao) error {
+	if len(publicacoes) == 0 {
+		return nil
+	}
+
+	ids := idsPublicacoes(publicacoes)
+	linhas, erro := db.Query(`select m.publicacao_id, m.usuario_id, u.nick, m.inicio, m.fim
+		from mencoes m
+		inner join usuarios u on u.id = m.usuario_id
+		where m.publicacao_id in (`+placeholders(len(ids))+`) order by m.inicio`, ids...)
+	if erro != nil {
+		return erro
+	}
+	defer linhas.Close()
+
+	porPublicacao := make(map[uint64][]models.Mencao)
+	for linhas.Next() {
+		var publicacaoId uint64
+		var mencao models.Mencao
+		if erro := linhas.Scan(&publicacaoId, &mencao.UsuarioId, &mencao.Nick, &mencao.Inicio, &mencao.Fim); erro != nil {
+			return erro
+		}
+		porPublicacao[publicacaoId] = append(porPublicacao[publicacaoId], mencao)
+	}
+
+	if erro := linhas.Err(); erro != nil {
+		return erro
+	}
+
+	for i := range publicacoes {
+		publicacoes[i].Mencoes = porPublicacao[publicacoes[i].Id]
+	}
+
+	return nil
+}
@@ -0,0 +1,424 @@
+package repositories
+
+import (
+	"api/src/midia"
+	"api/src/models"
+	"database/sql"
+	"fmt"
+)
+
+// mensagemVisivel filtra as mensagens m que o participante cp ainda vê: as posteriores à
+// conversa ser apagada por ele e que ele não apagou uma a uma
+const mensagemVisivel = `m.id > coalesce(cp.oculta_ate, 0)
+	and not exists (select 1 from mensagens_ocultas mo where mo.mensagem_id = m.id and mo.usuario_id = cp.usuario_id)`
+
+type Mensagens struct {
+	db *sql.DB
+}
+
+// Cria instancia de mensagens com banco para realizar as funções
+func NewMensagensRepo(db *sql.DB) *Mensagens {
+	return &Mensagens{db}
+}
+
+// Destinatarios informa, para cada usuário existente entre ids, se ele aceita mensagens do remetente.
+// Quem ativou MensagensApenasSeguidos só aceita de quem segue, e ninguém aceita com bloqueio entre os dois.
+// Ids inexistentes ficam fora do mapa
+func (repository Mensagens) Destinatarios(remetenteId uint64, ids []uint64) (map[uint64]bool, error) {
+	aceitam := make(map[uint64]bool, len(ids))
+	if len(ids) == 0 {
+		return aceitam, nil
+	}
+
+	args := []any{remetenteId, remetenteId, remetenteId}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	linhas, erro := repository.db.Query(`select u.id, (not u.mensagensApenasSeguidos
+		    or exists (select 1 from seguidores s where s.usuario_id = ? and s.seguidor_id = u.id))
+		    and `+semBloqueio("u.id")+`
+		from usuarios u where u.id in (`+placeholders(len(ids))+`)`, args...)
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	for linhas.Next() {
+		var id uint64
+		var aceita bool
+		if erro := linhas.Scan(&id, &aceita); erro != nil {
+			return nil, erro
+		}
+		aceitam[id] = aceita
+	}
+
+	return aceitam, linhas.Err()
+}
+
+// ComBloqueio informa se algum dos usuários bloqueou o remetente ou foi bloqueado por ele
+func (repository Mensagens) ComBloqueio(remetenteId uint64, ids []uint64) (bool, error) {
+	if len(ids) == 0 {
+		return false, nil
+	}
+
+	args := make([]any, 0, len(ids)+2)
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	args = append(args, remetenteId, remetenteId)
+
+	return existeLinha(repository.db, `select 1 from usuarios u
+		where u.id in (`+placeholders(len(ids))+`) and not `+semBloqueio("u.id"), args...)
+}
+
+// CriarConversa cria a conversa com o criador e os participantes do pedido. A conversa entre duas
+// pessoas é única: se já existir, seu id é retornado com criada false
+func (repository Mensagens) CriarConversa(criadorId uint64, request models.ConversaDiretaRequest) (conversaId uint64, criada bool, erro error) {
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return 0, false, erro
+	}
+	defer tx.Rollback()
+
+	var chave *string
+	if !request.Grupo() {
+		par := fmt.Sprintf("%d:%d", min(criadorId, request.Participantes[0]), max(criadorId, request.Participantes[0]))
+		chave = &par
+	}
+
+	// Em uma conversa que já existe, o update só recupera o id, sem alterar nenhuma linha
+	resultado, erro := tx.Exec(`insert into conversas (grupo, nome, chave, criador_id) values (?, ?, ?, ?)
+		on duplicate key update id = last_insert_id(id)`, request.Grupo(), request.Nome, chave, criadorId)
+	if erro != nil {
+		return 0, false, erro
+	}
+
+	id, erro := resultado.LastInsertId()
+	if erro != nil {
+		return 0, false, erro
+	}
+	afetadas, erro := resultado.RowsAffected()
+	if erro != nil {
+		return 0, false, erro
+	}
+
+	if afetadas == 1 {
+		for _, usuarioId := range append([]uint64{criadorId}, request.Participantes...) {
+			if _, erro := tx.Exec("insert into conversas_participantes (conversa_id, usuario_id) values (?, ?)",
+				id, usuarioId); erro != nil {
+				return 0, false, erro
+			}
+		}
+	}
+
+	return uint64(id), afetadas == 1, tx.Commit()
+}
+
+// Participantes retorna os ids dos participantes da conversa e se ela é um grupo.
+// Retorna sql.ErrNoRows se a conversa não existir
+func (repository Mensagens) Participantes(conversaId uint64) (ids []uint64, grupo bool, erro error) {
+	if erro := repository.db.QueryRow("select grupo from conversas where id = ?", conversaId).Scan(&grupo); erro != nil {
+		return nil, false, erro
+	}
+
+	linhas, erro := repository.db.Query("select usuario_id from conversas_participantes where conversa_id = ?", conversaId)
+	if erro != nil {
+		return nil, false, erro
+	}
+	defer linhas.Close()
+
+	for linhas.Next() {
+		var id uint64
+		if erro := linhas.Scan(&id); erro != nil {
+			return nil, false, erro
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, grupo, linhas.Err()
+}
+
+// BuscarConversas lista as conversas do usuário, das com atividade mais recente para as mais antigas.
+// Conversas apagadas por ele só voltam a aparecer quando chega uma mensagem nova
+func (repository Mensagens) BuscarConversas(usuarioId uint64, paginacao models.Paginacao) ([]models.ConversaDireta, error) {
+	linhas, erro := repository.db.Query(`select c.id, c.grupo, c.nome, c.atualizadaEm
+		from conversas c
+		inner join conversas_participantes cp on cp.conversa_id = c.id and cp.usuario_id = ?
+		where cp.oculta_ate is null
+		   or exists (select 1 from mensagens m where m.conversa_id = c.id and m.id > cp.oculta_ate)
+		order by c.atualizadaEm desc, c.id desc
+		limit ? offset ?`, usuarioId, paginacao.Limite, paginacao.Offset())
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	conversas := []models.ConversaDireta{}
+	for linhas.Next() {
+		var conversa models.ConversaDireta
+		if erro := linhas.Scan(&conversa.Id, &conversa.Grupo, &conversa.Nome, &conversa.AtualizadaEm); erro != nil {
+			return nil, erro
+		}
+		conversas = append(conversas, conversa)
+	}
+
+	if erro := linhas.Err(); erro != nil {
+		return nil, erro
+	}
+
+	if erro := repository.completarConversas(usuarioId, conversas); erro != nil {
+		return nil, erro
+	}
+
+	return conversas, nil
+}
+
+// BuscarConversa retorna a conversa do ponto de vista do participante.
+// Retorna sql.ErrNoRows se ela não existir ou ele não participar dela
+func (repository Mensagens) BuscarConversa(conversaId uint64, usuarioId uint64) (models.ConversaDireta, error) {
+	var conversa models.ConversaDireta
+	erro := repository.db.QueryRow(`select c.id, c.grupo, c.nome, c.atualizadaEm
+		from conversas c
+		inner join conversas_participantes cp on cp.conversa_id = c.id and cp.usuario_id = ?
+		where c.id = ?`, usuarioId, conversaId).Scan(&conversa.Id, &conversa.Grupo, &conversa.Nome, &conversa.AtualizadaEm)
+	if erro != nil {
+		return conversa, erro
+	}
+
+	conversas := []models.ConversaDireta{conversa}
+	if erro := repository.completarConversas(usuarioId, conversas); erro != nil {
+		return conversa, erro
+	}
+
+	return conversas[0], nil
+}
+
+// completarConversas preenche participantes, última mensagem e não lidas com uma consulta para cada
+func (repository Mensagens) completarConversas(usuarioId uint64, conversas []models.ConversaDireta) error {
+	if len(conversas) == 0 {
+		return nil
+	}
+
+	ids := make([]any, 0, len(conversas))
+	indices := make(map[uint64]int, len(conversas))
+	for i, conversa := range conversas {
+		ids = append(ids, conversa.Id)
+		indices[conversa.Id] = i
+	}
+
+	linhas, erro := repository.db.Query(`select cp.conversa_id, cp.usuario_id, u.nick, u.avatar, cp.ultima_lida_id
+		from conversas_participantes cp
+		inner join usuarios u on u.id = cp.usuario_id
+		where cp.conversa_id in (`+placeholders(len(ids))+`)
+		order by cp.entrouEm, cp.usuario_id`, ids...)
+	if erro != nil {
+		return erro
+	}
+	defer linhas.Close()
+
+	for linhas.Next() {
+		var conversaId uint64
+		var participante models.ParticipanteConversa
+		var avatar sql.NullString
+		if erro := linhas.Scan(&conversaId, &participante.UsuarioId, &participante.Nick, &avatar,
+			&participante.UltimaLidaId); erro != nil {
+			return erro
+		}
+		participante.Avatar = ImagemPerfil(avatar, midia.TamanhosAvatar)
+		conversa := &conversas[indices[conversaId]]
+		conversa.Participantes = append(conversa.Participantes, participante)
+	}
+	if erro := linhas.Err(); erro != nil {
+		return erro
+	}
+	linhas.Close()
+
+	ultimas, erro := repository.buscarMensagens(`select m.id, m.conversa_id, m.autor_id, u.nick, m.texto, m.criadaEm
+		from mensagens m
+		inner join usuarios u on u.id = m.autor_id
+		where m.id in (
+		    select max(m.id) from mensagens m
+		    inner join conversas_participantes cp on cp.conversa_id = m.conversa_id and cp.usuario_id = ?
+		    where m.conversa_id in (`+placeholders(len(ids))+`) and `+mensagemVisivel+`
+		    group by m.conversa_id
+		)`, append([]any{usuarioId}, ids...)...)
+	if erro != nil {
+		return erro
+	}
+	for _, mensagem := range ultimas {
+		conversas[indices[mensagem.ConversaId]].UltimaMensagem = &mensagem
+	}
+
+	linhas, erro = repository.db.Query(`select m.conversa_id, count(*) from mensagens m
+		inner join conversas_participantes cp on cp.conversa_id = m.conversa_id and cp.usuario_id = ?
+		where m.conversa_id in (`+placeholders(len(ids))+`) and m.autor_id <> cp.usuario_id
+		  and m.id > coalesce(cp.ultima_lida_id, 0) and `+mensagemVisivel+`
+		group by m.conversa_id`, append([]any{usuarioId}, ids...)...)
+	if erro != nil {
+		return erro
+	}
+	defer linhas.Close()
+
+	for linhas.Next() {
+		var conversaId, naoLidas uint64
+		if erro := linhas.Scan(&conversaId, &naoLidas); erro != nil {
+			return erro
+		}
+		conversas[indices[conversaId]].NaoLidas = naoLidas
+	}
+
+	return linhas.Err()
+}
+
+// BuscarMensagens retorna até limite mensagens visíveis ao participante, das mais recentes para as
+// mais antigas, começando antes da mensagem antes (0 para começar da mais recente)
+func (repository Mensagens) BuscarMensagens(conversaId uint64, usuarioId uint64, antes uint64, limite uint64) (models.PaginaMensagens, error) {
+	pagina := models.PaginaMensagens{}
+
+	// Uma a mais que o limite indica que ainda há mensagens anteriores
+	mensagens, erro := repository.buscarMensagens(`select m.id, m.conversa_id, m.autor_id, u.nick, m.texto, m.criadaEm
+		from mensagens m
+		inner join usuarios u on u.id = m.autor_id
+		inner join conversas_participantes cp on cp.conversa_id = m.conversa_id and cp.usuario_id = ?
+		where m.conversa_id = ? and (? = 0 or m.id < ?) and `+mensagemVisivel+`
+		order by m.id desc
+		limit ?`, usuarioId, conversaId, antes, antes, limite+1)
+	if erro != nil {
+		return pagina, erro
+	}
+
+	if uint64(len(mensagens)) > limite {
+		mensagens = mensagens[:limite]
+		cursor := mensagens[len(mensagens)-1].Id
+		pagina.ProximoCursor = &cursor
+	}
+	pagina.Mensagens = mensagens
+
+	return pagina, nil
+}
+
+func (repository Mensagens) buscarMensagens(query string, args ...any) ([]models.Mensagem, error) {
+	linhas, erro := repository.db.Query(query, args...)
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	mensagens := []models.Mensagem{}
+	for linhas.Next() {
+		var mensagem models.Mensagem
+		if erro := linhas.Scan(&mensagem.Id, &mensagem.ConversaId, &mensagem.AutorId, &mensagem.AutorNick,
+			&mensagem.Texto, &mensagem.CriadaEm); erro != nil {
+			return nil, erro
+		}
+		mensagens = append(mensagens, mensagem)
+	}
+
+	return mensagens, linhas.Err()
+}
+
+// Enviar grava a mensagem, que já conta como lida para o autor, e avisa os participantes em tempo real
+func (repository Mensagens) Enviar(conversaId uint64, autorId uint64, texto string) (models.Mensagem, error) {
+	mensagem := models.Mensagem{ConversaId: conversaId, AutorId: autorId, Texto: texto}
+
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return mensagem, erro
+	}
+	defer tx.Rollback()
+
+	resultado, erro := tx.Exec("insert into mensagens (conversa_id, autor_id, texto) values (?, ?, ?)",
+		conversaId, autorId, texto)
+	if erro != nil {
+		return mensagem, erro
+	}
+
+	id, erro := resultado.LastInsertId()
+	if erro != nil {
+		return mensagem, erro
+	}
+	mensagem.Id = uint64(id)
+
+	if erro := tx.QueryRow(`select u.nick, m.criadaEm from mensagens m
+		inner join usuarios u on u.id = m.autor_id where m.id = ?`, mensagem.Id).Scan(&mensagem.AutorNick, &mensagem.CriadaEm); erro != nil {
+		return mensagem, erro
+	}
+
+	if _, erro := tx.Exec("update conversas set atualizadaEm = current_timestamp() where id = ?", conversaId); erro != nil {
+		return mensagem, erro
+	}
+
+	if _, erro := tx.Exec("update conversas_participantes set ultima_lida_id = ? where conversa_id = ? and usuario_id = ?",
+		mensagem.Id, conversaId, autorId); erro != nil {
+		return mensagem, erro
+	}
+
+	linhas, erro := tx.Query("select usuario_id from conversas_participantes where conversa_id = ?", conversaId)
+	if erro != nil {
+		return mensagem, erro
+	}
+	participantes := []uint64{}
+	for linhas.Next() {
+		var id uint64
+		if erro := linhas.Scan(&id); erro != nil {
+			linhas.Close()
+			return mensagem, erro
+		}
+		participantes = append(participantes, id)
+	}
+	linhas.Close()
+
+	for _, participanteId := range participantes {
+		if erro := registrarEvento(tx, models.EventoMensagem, &participanteId, nil, mensagem); erro != nil {
+			return mensagem, erro
+		}
+	}
+
+	return mensagem, tx.Commit()
+}
+
+// MarcarLida marca como lidas, para o participante, todas as mensagens da conversa até agora
+func (repository Mensagens) MarcarLida(conversaId uint64, usuarioId uint64) error {
+	_, erro := repository.db.Exec(`update conversas_participantes
+		set ultima_lida_id = (select max(id) from mensagens where conversa_id = ?)
+		where conversa_id = ? and usuario_id = ?`, conversaId, conversaId, usuarioId)
+	return erro
+}
+
+// ContarNaoLidas conta as mensagens não lidas do usuário e em quantas conversas elas estão
+func (repository Mensagens) ContarNaoLidas(usuarioId uint64) (models.ContagemMensagens, error) {
+	var contagem models.ContagemMensagens
+	erro := repository.db.QueryRow(`select count(*), count(distinct m.conversa_id) from mensagens m
+		inner join conversas_participantes cp on cp.conversa_id = m.conversa_id and cp.usuario_id = ?
+		where m.autor_id <> cp.usuario_id and m.id > coalesce(cp.ultima_lida_id, 0) and `+mensagemVisivel,
+		usuarioId).Scan(&contagem.NaoLidas, &contagem.Conversas)
+	return contagem, erro
+}
+
+// OcultarMensagem apaga a mensagem só para o participante. Retorna false se ela não for da conversa
+func (repository Mensagens) OcultarMensagem(conversaId uint64, mensagemId uint64, usuarioId uint64) (bool, error) {
+	var encontrada bool
+	erro := repository.db.QueryRow("select 1 from mensagens where id = ? and conversa_id = ?",
+		mensagemId, conversaId).Scan(&encontrada)
+	if erro == sql.ErrNoRows {
+		return false, nil
+	}
+	if erro != nil {
+		return false, erro
+	}
+
+	_, erro = repository.db.Exec("insert ignore into mensagens_ocultas (mensagem_id, usuario_id) values (?, ?)",
+		mensagemId, usuarioId)
+	return true, erro
+}
+
+// OcultarConversa apaga a conversa só para o participante: as mensagens até agora deixam de aparecer
+// para ele, e a conversa some da lista até chegar uma mensagem nova
+func (repository Mensagens) OcultarConversa(conversaId uint64, usuarioId uint64) error {
+	_, erro := repository.db.Exec(`update conversas_participantes cp
+		inner join (select coalesce(max(id), 0) as ultima from mensagens where conversa_id = ?) m
+		set cp.oculta_ate = m.ultima, cp.ultima_lida_id = greatest(coalesce(cp.ultima_lida_id, 0), m.ultima)
+		where cp.conversa_id = ? and cp.usuario_id = ?`, conversaId, conversaId, usuarioId)
+	return erro
+}
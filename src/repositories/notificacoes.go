@@ -0,0 +1,305 @@
+package repositories
+
+import (
+	"api/src/midia"
+	"api/src/models"
+	"database/sql"
+)
+
+// atoresPorNotificacao é quantos autores da ação vêm em cada notificação agrupada
+const atoresPorNotificacao = 3
+
+type Notificacoes struct {
+	db *sql.DB
+}
+
+// Cria instancia de notificacoes com banco para realizar as funções
+func NewNotificacoesRepo(db *sql.DB) *Notificacoes {
+	return &Notificacoes{db}
+}
+
+// notificar registra que atorId fez uma ação do tipo para usuarioId. Enquanto a notificação do mesmo
+// tipo e publicação não for lida, o ator entra nela em vez de criar outra. Não notifica ações do
+// próprio usuário nem tipos que ele desativou nas preferências.
+// É chamado dentro da mesma transação da ação, pelos repositórios que geram os eventos
+func notificar(exec executor, tipo string, usuarioId uint64, atorId uint64, publicacaoId *uint64) error {
+	if usuarioId == atorId {
+		return nil
+	}
+
+	linhas, erro := exec.Query("select 1 from preferencias_notificacao where usuario_id = ? and tipo = ? and not ativa",
+		usuarioId, tipo)
+	if erro != nil {
+		return erro
+	}
+	desativada := linhas.Next()
+	linhas.Close()
+	if desativada {
+		return nil
+	}
+
+	linhas, erro = exec.Query(`select id from notificacoes
+		where usuario_id = ? and tipo = ? and publicacao_id <=> ? and not lida
+		order by id desc limit 1 for update`, usuarioId, tipo, publicacaoId)
+	if erro != nil {
+		return erro
+	}
+
+	var notificacaoId sql.NullInt64
+	if linhas.Next() {
+		erro = linhas.Scan(&notificacaoId)
+	}
+	linhas.Close()
+	if erro != nil {
+		return erro
+	}
+
+	if !notificacaoId.Valid {
+		insercao, erro := exec.Exec("insert into notificacoes (usuario_id, tipo, publicacao_id) values (?, ?, ?)",
+			usuarioId, tipo, publicacaoId)
+		if erro != nil {
+			return erro
+		}
+		if notificacaoId.Int64, erro = insercao.LastInsertId(); erro != nil {
+			return erro
+		}
+	}
+
+	if _, erro := exec.Exec(`insert into notificacoes_atores (notificacao_id, ator_id) values (?, ?)
+		on duplicate key update criadoEm = current_timestamp()`, notificacaoId.Int64, atorId); erro != nil {
+		return erro
+	}
+
+	if _, erro := exec.Exec("update notificacoes set atualizadaEm = current_timestamp() where id = ?", notificacaoId.Int64); erro != nil {
+		return erro
+	}
+
+	return registrarEvento(exec, models.EventoNotificacao, &usuarioId, nil,
+		models.DadosEventoNotificacao{NotificacaoId: uint64(notificacaoId.Int64), Tipo: tipo})
+}
+
+// autorPublicada retorna o autor da publicação, se ela já foi publicada
+func autorPublicada(exec executor, publicacaoId uint64) (autorId uint64, encontrada bool, erro error) {
+	linhas, erro := exec.Query("select autor_id from publicacoes where id = ? and status = 'publicada'", publicacaoId)
+	if erro != nil {
+		return 0, false, erro
+	}
+	defer linhas.Close()
+
+	if encontrada = linhas.Next(); encontrada {
+		erro = linhas.Scan(&autorId)
+	}
+	return autorId, encontrada, erro
+}
+
+// notificarAutor notifica o autor da publicação. Publicações ainda não publicadas não geram notificação
+func notificarAutor(exec executor, tipo string, publicacaoId uint64, atorId uint64) error {
+	autorId, encontrada, erro := autorPublicada(exec, publicacaoId)
+	if erro != nil || !encontrada {
+		return erro
+	}
+
+	return notificar(exec, tipo, autorId, atorId, &publicacaoId)
+}
+
+// desfazerNotificacao tira o ator das notificações ainda não lidas de usuarioId, quando a ação
+// é desfeita (descurtir, deixar de seguir). As que ficam sem nenhum ator são apagadas
+func desfazerNotificacao(exec executor, tipo string, usuarioId uint64, atorId uint64, publicacaoId *uint64) error {
+	if _, erro := exec.Exec(`delete na from notificacoes_atores na
+		inner join notificacoes n on n.id = na.notificacao_id
+		where n.usuario_id = ? and n.tipo = ? and n.publicacao_id <=> ? and not n.lida and na.ator_id = ?`,
+		usuarioId, tipo, publicacaoId, atorId); erro != nil {
+		return erro
+	}
+
+	_, erro := exec.Exec(`delete from notificacoes
+		where usuario_id = ? and tipo = ? and publicacao_id <=> ? and not lida
+		  and not exists (select 1 from notificacoes_atores na where na.notificacao_id = notificacoes.id)`,
+		usuarioId, tipo, publicacaoId)
+	return erro
+}
+
+// desfazerNotificacaoAutor é desfazerNotificacao para ações sobre uma publicação
+func desfazerNotificacaoAutor(exec executor, tipo string, publicacaoId uint64, atorId uint64) error {
+	autorId, encontrada, erro := autorPublicada(exec, publicacaoId)
+	if erro != nil || !encontrada {
+		return erro
+	}
+
+	return desfazerNotificacao(exec, tipo, autorId, atorId, &publicacaoId)
+}
+
+// Buscar lista as notificações do usuário, das atualizadas mais recentemente para as mais antigas
+func (repository Notificacoes) Buscar(usuarioId uint64, apenasNaoLidas bool, paginacao models.Paginacao) ([]models.Notificacao, error) {
+	linhas, erro := repository.db.Query(`select n.id, n.tipo, n.publicacao_id, n.lida, n.atualizadaEm,
+		       (select count(*) from notificacoes_atores na where na.notificacao_id = n.id) as atores
+		from notificacoes n
+		where n.usuario_id = ? and (not ? or not n.lida)
+		having atores > 0
+		order by n.atualizadaEm desc, n.id desc
+		limit ? offset ?`, usuarioId, apenasNaoLidas, paginacao.Limite, paginacao.Offset())
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	notificacoes := []models.Notificacao{}
+	for linhas.Next() {
+		var notificacao models.Notificacao
+		if erro := linhas.Scan(&notificacao.Id, &notificacao.Tipo, &notificacao.PublicacaoId,
+			&notificacao.Lida, &notificacao.AtualizadaEm, &notificacao.TotalAtores); erro != nil {
+			return nil, erro
+		}
+		notificacoes = append(notificacoes, notificacao)
+	}
+
+	if erro := linhas.Err(); erro != nil {
+		return nil, erro
+	}
+
+	if erro := carregarAtores(repository.db, notificacoes); erro != nil {
+		return nil, erro
+	}
+
+	for i := range notificacoes {
+		notificacoes[i].MontarTexto()
+	}
+
+	return notificacoes, nil
+}
+
+// carregarAtores preenche os atores mais recentes de cada notificação com uma única consulta
+func carregarAtores(db *sql.DB, notificacoes []models.Notificacao) error {
+	if len(notificacoes) == 0 {
+		return nil
+	}
+
+	ids := make([]any, 0, len(notificacoes))
+	for _, notificacao := range notificacoes {
+		ids = append(ids, notificacao.Id)
+	}
+
+	linhas, erro := db.Query(`select notificacao_id, ator_id, nick, avatar from (
+		    select na.notificacao_id, na.ator_id, u.nick, u.avatar,
+		           row_number() over (partition by na.notificacao_id order by na.criadoEm desc, na.ator_id) as posicao
+		    from notificacoes_atores na
+		    inner join usuarios u on u.id = na.ator_id
+		    where na.notificacao_id in (`+placeholders(len(ids))+`)
+		) recentes
+		where posicao <= ?
+		order by notificacao_id, posicao`, append(ids, atoresPorNotificacao)...)
+	if erro != nil {
+		return erro
+	}
+	defer linhas.Close()
+
+	porNotificacao := make(map[uint64][]models.AtorNotificacao)
+	for linhas.Next() {
+		var notificacaoId uint64
+		var ator models.AtorNotificacao
+		var avatar sql.NullString
+		if erro := linhas.Scan(&notificacaoId, &ator.UsuarioId, &ator.Nick, &avatar); erro != nil {
+			return erro
+		}
+		ator.Avatar = ImagemPerfil(avatar, midia.TamanhosAvatar)
+		porNotificacao[notificacaoId] = append(porNotificacao[notificacaoId], ator)
+	}
+
+	if erro := linhas.Err(); erro != nil {
+		return erro
+	}
+
+	for i := range notificacoes {
+		notificacoes[i].Atores = porNotificacao[notificacoes[i].Id]
+	}
+
+	return nil
+}
+
+// ContarNaoLidas conta as notificações não lidas do usuário por tipo
+func (repository Notificacoes) ContarNaoLidas(usuarioId uint64) (models.ContagemNotificacoes, error) {
+	contagem := models.ContagemNotificacoes{PorTipo: map[string]uint64{}}
+
+	linhas, erro := repository.db.Query(`select n.tipo, count(*) from notificacoes n
+		where n.usuario_id = ? and not n.lida
+		  and exists (select 1 from notificacoes_atores na where na.notificacao_id = n.id)
+		group by n.tipo`, usuarioId)
+	if erro != nil {
+		return contagem, erro
+	}
+	defer linhas.Close()
+
+	for linhas.Next() {
+		var tipo string
+		var quantidade uint64
+		if erro := linhas.Scan(&tipo, &quantidade); erro != nil {
+			return contagem, erro
+		}
+		contagem.PorTipo[tipo] = quantidade
+		contagem.NaoLidas += quantidade
+	}
+
+	return contagem, linhas.Err()
+}
+
+// MarcarLida marca a notificação do usuário como lida. Retorna sql.ErrNoRows se ela não for dele
+func (repository Notificacoes) MarcarLida(notificacaoId uint64, usuarioId uint64) error {
+	var existe bool
+	erro := repository.db.QueryRow("select 1 from notificacoes where id = ? and usuario_id = ?",
+		notificacaoId, usuarioId).Scan(&existe)
+	if erro != nil {
+		return erro
+	}
+
+	_, erro = repository.db.Exec("update notificacoes set lida = true where id = ?", notificacaoId)
+	return erro
+}
+
+// MarcarTodasLidas marca como lidas todas as notificações do usuário
+func (repository Notificacoes) MarcarTodasLidas(usuarioId uint64) error {
+	_, erro := repository.db.Exec("update notificacoes set lida = true where usuario_id = ? and not lida", usuarioId)
+	return erro
+}
+
+// BuscarPreferencias retorna a preferência de cada tipo. Tipos nunca alterados vêm ativos
+func (repository Notificacoes) BuscarPreferencias(usuarioId uint64) (models.PreferenciasNotificacao, error) {
+	preferencias := models.PreferenciasNotificacao{}
+	for _, tipo := range models.TiposNotificacao {
+		preferencias[tipo] = true
+	}
+
+	linhas, erro := repository.db.Query("select tipo, ativa from preferencias_notificacao where usuario_id = ?", usuarioId)
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	for linhas.Next() {
+		var tipo string
+		var ativa bool
+		if erro := linhas.Scan(&tipo, &ativa); erro != nil {
+			return nil, erro
+		}
+		preferencias[tipo] = ativa
+	}
+
+	return preferencias, linhas.Err()
+}
+
+// AtualizarPreferencias grava só os tipos informados, mantendo os demais como estão
+func (repository Notificacoes) AtualizarPreferencias(usuarioId uint64, preferencias models.PreferenciasNotificacao) error {
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return erro
+	}
+	defer tx.Rollback()
+
+	for tipo, ativa := range preferencias {
+		if _, erro := tx.Exec(`insert into preferencias_notificacao (usuario_id, tipo, ativa) values (?, ?, ?)
+			on duplicate key update ativa = values(ativa)`, usuarioId, tipo, ativa); erro != nil {
+			return erro
+		}
+	}
+
+	return tx.Commit()
+}
@@ -1,8 +1,12 @@
 package repositories
 
 import (
+	"api/src/diff"
+	"api/src/midia"
 	"api/src/models"
 	"database/sql"
+	"strings"
+	"time"
 )
 
 type Publicacoes struct {
@@ -16,14 +20,16 @@ func NewPublicacoesRepo(db *sql.DB) *Publicacoes {
 }
 
 func (repository Publicacoes) Criar(usuarioId uint64, publicacao models.Publicacao) (uint64, error) {
-	statement, erro := repository.db.Prepare("insert into publicacoes (titulo, conteudo, autor_id) values (?,?,?)")
-
+	tx, erro := repository.db.Begin()
 	if erro != nil {
 		return 0, erro
 	}
-	defer statement.Close()
+	defer tx.Rollback()
 
-	insercao, erro := statement.Exec(publicacao.Titulo, publicacao.Conteudo, usuarioId)
+	insercao, erro := tx.Exec(`insert into publicacoes
+		(titulo, conteudo, autor_id, em_resposta_a, citacao_de, status, publicarEm, visibilidade) values (?,?,?,?,?,?,?,?)`,
+		publicacao.Titulo, publicacao.Conteudo, usuarioId, publicacao.EmRespostaA, publicacao.CitacaoDe,
+		publicacao.Status, publicacao.PublicarEm, publicacao.Visibilidade)
 
 	if erro != nil {
 		return 0, erro
@@ -34,68 +40,351 @@ func (repository Publicacoes) Criar(usuarioId uint64, publicacao models.Publicac
 		return 0, erro
 	}
 
-	return uint64(idInserido), nil
+	if erro := salvarTags(tx, uint64(idInserido), publicacao.Tags); erro != nil {
+		return 0, erro
+	}
+
+	if erro := salvarMencoes(tx, uint64(idInserido), publicacao.Mencoes); erro != nil {
+		return 0, erro
+	}
+
+	if erro := notificarMencoes(tx, idInserido); erro != nil {
+		return 0, erro
+	}
+
+	if publicacao.Status == models.StatusPublicada {
+		if erro := registrarEvento(tx, models.EventoPublicacao, nil, &usuarioId,
+			models.DadosEventoPublicacao{PublicacaoId: uint64(idInserido)}); erro != nil {
+			return 0, erro
+		}
+	}
+
+	return uint64(idInserido), tx.Commit()
 }
 
-func (repository Publicacoes) BuscarPorId(id uint64) (models.Publicacao, error) {
-	publicacao := models.Publicacao{}
-	linha, erro := repository.db.Query(`SELECT p.id, p.titulo, p.conteudo, p.autor_id, p.criadaEm,
-       COUNT(c.publicacao_id) AS curtidas,
-       u.nick FROM publicacoes p
-	   INNER JOIN usuarios u ON u.id = p.autor_id
-	   LEFT JOIN curtidas c ON c.publicacao_id = p.id
-	   WHERE p.id = ?
-	   GROUP BY p.id, p.titulo, p.conteudo, p.autor_id, p.criadaEm, u.nick`, id)
+// colunasPublicacao são as colunas lidas por scanPublicacao, na mesma ordem.
+// As contagens são subconsultas para que um JOIN não multiplique a outra.
+// Curtidas e reações são preenchidas depois, por carregarReacoes.
+// Toda consulta vista por outros usuários precisa filtrar p.status = 'publicada'
+const colunasPublicacao = `p.id, p.titulo, p.conteudo, p.autor_id, p.em_resposta_a, p.citacao_de, p.criadaEm,
+       p.editadaEm, p.edicoes, p.status, p.publicarEm, p.visibilidade,
+       (SELECT COUNT(*) FROM comentarios cm WHERE cm.publicacao_id = p.id) AS comentarios,
+       (SELECT COUNT(*) FROM publicacoes r WHERE r.em_resposta_a = p.id AND r.status = 'publicada') AS respostas,
+       (SELECT COUNT(*) FROM republicacoes rp WHERE rp.publicacao_id = p.id) AS republicacoes,
+       (SELECT COUNT(*) FROM publicacoes q WHERE q.citacao_de = p.id AND q.status = 'publicada') AS citacoes,
+       u.nick, u.avatar`
+
+const selectPublicacao = `SELECT ` + colunasPublicacao + ` FROM publicacoes p
+	   INNER JOIN usuarios u ON u.id = p.autor_id`
+
+// scanner é implementado por *sql.Row e *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// executor é implementado por *sql.DB e *sql.Tx, para que o mesmo código rode dentro ou fora de transação
+type executor interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// scanPublicacao lê as colunas de colunasPublicacao seguidas dos destinos extras
+func scanPublicacao(linha scanner, extras ...any) (models.Publicacao, error) {
+	var publicacao models.Publicacao
+	var autorAvatar sql.NullString
+	destinos := append([]any{&publicacao.Id, &publicacao.Titulo, &publicacao.Conteudo,
+		&publicacao.AutorId, &publicacao.EmRespostaA, &publicacao.CitacaoDe, &publicacao.CriadaEm,
+		&publicacao.EditadaEm, &publicacao.Edicoes, &publicacao.Status, &publicacao.PublicarEm,
+		&publicacao.Visibilidade, &publicacao.Comentarios, &publicacao.Respostas,
+		&publicacao.Republicacoes, &publicacao.Citacoes, &publicacao.AutorNick, &autorAvatar}, extras...)
+	erro := linha.Scan(destinos...)
+	publicacao.AutorAvatar = ImagemPerfil(autorAvatar, midia.TamanhosAvatar)
+	return publicacao, erro
+}
+
+// buscarListaPublicacoes lê e completa as publicações da consulta. O visitante é quem vai ver a
+// lista, e só as citações que ele pode ver são embutidas
+func buscarListaPublicacoes(db *sql.DB, visitanteId uint64, query string, args ...any) ([]models.Publicacao, error) {
+	linhas, erro := db.Query(query, args...)
 	if erro != nil {
-		return publicacao, erro
+		return nil, erro
+	}
+
+	defer linhas.Close()
+
+	publicacoes := []models.Publicacao{}
+
+	for linhas.Next() {
+		publicacao, erro := scanPublicacao(linhas)
+		if erro != nil {
+			return nil, erro
+		}
+		publicacoes = append(publicacoes, publicacao)
+	}
+
+	if erro := linhas.Err(); erro != nil {
+		return nil, erro
+	}
+
+	return publicacoes, completarPublicacoes(db, publicacoes, visitanteId)
+}
+
+// completarPublicacoes preenche os dados que vêm de consultas em lote
+// sobre a lista toda, em vez de uma consulta por publicação
+func completarPublicacoes(db *sql.DB, publicacoes []models.Publicacao, visitanteId uint64) error {
+	if erro := carregarDetalhes(db, publicacoes); erro != nil {
+		return erro
+	}
+	if erro := carregarEstadoVisitante(db, publicacoes, visitanteId); erro != nil {
+		return erro
+	}
+	return carregarCitacoes(db, publicacoes, visitanteId)
+}
+
+// carregarDetalhes preenche o que pertence a cada publicação: reações, anexos, tags e menções.
+// Também é usado nas publicações citadas
+func carregarDetalhes(db *sql.DB, publicacoes []models.Publicacao) error {
+	if erro := carregarReacoes(db, publicacoes); erro != nil {
+		return erro
+	}
+	if erro := carregarAnexos(db, publicacoes); erro != nil {
+		return erro
+	}
+	if erro := carregarTags(db, publicacoes); erro != nil {
+		return erro
+	}
+	return carregarMencoes(db, publicacoes)
+}
+
+// idsPublicacoes retorna os ids das publicações prontos para uma cláusula IN
+func idsPublicacoes(publicacoes []models.Publicacao) []any {
+	ids := make([]any, 0, len(publicacoes))
+	for _, publicacao := range publicacoes {
+		ids = append(ids, publicacao.Id)
+	}
+	return ids
+}
+
+// placeholders retorna "?,?,...,?" com a quantidade informada de parâmetros
+func placeholders(quantidade int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", quantidade), ",")
+}
+
+// carregarReacoes preenche a contagem de reações por tipo de cada publicação.
+// Curtidas é a contagem do tipo "curtir"
+func carregarReacoes(db *sql.DB, publicacoes []models.Publicacao) error {
+	if len(publicacoes) == 0 {
+		return nil
 	}
 
-	// 	linha, erro := repository.db.Query(`select p.*, u.nick from publicacoes p
-	// inner join usuarios u on u.id = p.autor_id where p.id = ? order by 1 desc`, id)
+	ids := idsPublicacoes(publicacoes)
+	linhas, erro := db.Query(`SELECT publicacao_id, tipo, COUNT(*) FROM reacoes
+	   WHERE publicacao_id IN (`+placeholders(len(ids))+`)
+	   GROUP BY publicacao_id, tipo`, ids...)
+	if erro != nil {
+		return erro
+	}
 
-	defer linha.Close()
+	defer linhas.Close()
 
-	if linha.Next() {
-		if erro := linha.Scan(&publicacao.Id, &publicacao.Titulo, &publicacao.Conteudo,
-			&publicacao.AutorId, &publicacao.CriadaEm, &publicacao.Curtidas,
-			&publicacao.AutorNick); erro != nil {
-			return publicacao, erro
+	contagens := make(map[uint64]map[string]uint64)
+	for linhas.Next() {
+		var (
+			publicacaoId uint64
+			tipo         string
+			quantidade   uint64
+		)
+		if erro := linhas.Scan(&publicacaoId, &tipo, &quantidade); erro != nil {
+			return erro
 		}
-		return publicacao, nil
+		if contagens[publicacaoId] == nil {
+			contagens[publicacaoId] = make(map[string]uint64)
+		}
+		contagens[publicacaoId][tipo] = quantidade
+	}
+
+	if erro := linhas.Err(); erro != nil {
+		return erro
 	}
 
-	return publicacao, sql.ErrNoRows
+	for i := range publicacoes {
+		publicacoes[i].Reacoes = contagens[publicacoes[i].Id]
+		if publicacoes[i].Reacoes == nil {
+			publicacoes[i].Reacoes = map[string]uint64{}
+		}
+		publicacoes[i].Curtidas = publicacoes[i].Reacoes[models.ReacaoCurtir]
+	}
 
+	return nil
 }
 
+// carregarCitacoes preenche a publicação citada de cada item com uma única consulta.
+// A citação embutida não traz a citação dela, apenas o CitacaoDe. Citações que o visitante
+// não pode ver ficam de fora, como se tivessem sido apagadas
+func carregarCitacoes(db *sql.DB, publicacoes []models.Publicacao, visitanteId uint64) error {
+	ids := []any{}
+	for _, publicacao := range publicacoes {
+		if publicacao.CitacaoDe != nil {
+			ids = append(ids, *publicacao.CitacaoDe)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	linhas, erro := db.Query(selectPublicacao+`
+	   WHERE p.status = 'publicada' AND p.id IN (`+placeholders(len(ids))+`) AND `+publicacaoVisivel,
+		append(ids, argsVisivel(visitanteId)...)...)
+	if erro != nil {
+		return erro
+	}
+
+	defer linhas.Close()
+
+	lista := []models.Publicacao{}
+	for linhas.Next() {
+		citada, erro := scanPublicacao(linhas)
+		if erro != nil {
+			return erro
+		}
+		lista = append(lista, citada)
+	}
+
+	if erro := linhas.Err(); erro != nil {
+		return erro
+	}
+
+	if erro := carregarDetalhes(db, lista); erro != nil {
+		return erro
+	}
+	if erro := carregarEstadoVisitante(db, lista, visitanteId); erro != nil {
+		return erro
+	}
+
+	citadas := make(map[uint64]models.Publicacao)
+	for _, citada := range lista {
+		citadas[citada.Id] = citada
+	}
+
+	for i := range publicacoes {
+		if publicacoes[i].CitacaoDe == nil {
+			continue
+		}
+		if citada, ok := citadas[*publicacoes[i].CitacaoDe]; ok {
+			publicacoes[i].Citacao = &citada
+		}
+	}
+
+	return nil
+}
+
+// BuscarPorId retorna a publicação publicada ou sql.ErrNoRows, também quando o visitante não pode vê-la
+func (repository Publicacoes) BuscarPorId(id uint64, visitanteId uint64) (models.Publicacao, error) {
+	linha := repository.db.QueryRow(selectPublicacao+`
+	   WHERE p.id = ? AND p.status = 'publicada' AND `+publicacaoVisivel,
+		append([]any{id}, argsVisivel(visitanteId)...)...)
+
+	publicacao, erro := scanPublicacao(linha)
+	if erro != nil {
+		return publicacao, erro
+	}
+
+	publicacoes := []models.Publicacao{publicacao}
+	if erro := completarPublicacoes(repository.db, publicacoes, visitanteId); erro != nil {
+		return publicacao, erro
+	}
+
+	return publicacoes[0], nil
+}
+
+// BuscarPorIds retorna as publicações informadas que o visitante pode ver, sem ordem definida
+func (repository Publicacoes) BuscarPorIds(ids []uint64, visitanteId uint64) ([]models.Publicacao, error) {
+	if len(ids) == 0 {
+		return []models.Publicacao{}, nil
+	}
+
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	args = append(args, argsVisivel(visitanteId)...)
+
+	return buscarListaPublicacoes(repository.db, visitanteId, selectPublicacao+`
+	   WHERE p.id IN (`+placeholders(len(ids))+`) AND p.status = 'publicada' AND `+publicacaoVisivel, args...)
+}
+
+// BuscarPublicacoes monta o feed do usuário: as publicações dele e de quem ele segue,
+// junto com as republicações feitas por essas mesmas pessoas, da mais recente para a mais antiga.
+// Ficam de fora as publicações que ele não pode ver e as de quem ele silenciou,
+// inclusive quando republicadas por outra pessoa
 func (repository Publicacoes) BuscarPublicacoes(usuarioId uint64) ([]models.Publicacao, error) {
-	publicacoes := []models.Publicacao{}
-	linhas, erro := repository.db.Query(`SELECT p.id, p.titulo, p.conteudo, p.autor_id, p.criadaEm,
-       COUNT(c.publicacao_id) AS curtidas,
-       u.nick FROM publicacoes p
+	args := append([]any{usuarioId, usuarioId}, argsVisivel(usuarioId)...)
+	args = append(args, usuarioId, usuarioId, usuarioId)
+	args = append(args, argsVisivel(usuarioId)...)
+	args = append(args, usuarioId, usuarioId)
+
+	linhas, erro := repository.db.Query(`SELECT `+colunasPublicacao+`,
+	       NULL AS republicador_id, NULL AS republicador_nick, NULL AS republicador_avatar, NULL AS republicada_em,
+	       p.criadaEm AS momento
+	   FROM publicacoes p
 	   INNER JOIN usuarios u ON u.id = p.autor_id
-	   LEFT JOIN seguidores s on p.autor_id = s.usuario_id
-	   LEFT JOIN curtidas c ON c.publicacao_id = p.id
-	   where u.id = ? or s.seguidor_id = ?
-	   GROUP BY p.id, p.titulo, p.conteudo, p.autor_id, p.criadaEm, u.nick
-	   ORDER BY 1 DESC
-	`, usuarioId, usuarioId)
-
+	   WHERE p.status = 'publicada'
+	     AND (p.autor_id = ?
+	      OR p.autor_id IN (SELECT s.usuario_id FROM seguidores s WHERE s.seguidor_id = ?))
+	     AND `+publicacaoVisivel+` AND `+semSilenciado("p.autor_id")+`
+	   UNION ALL
+	   SELECT `+colunasPublicacao+`,
+	       rep.usuario_id, ur.nick, ur.avatar, rep.criadaEm,
+	       rep.criadaEm
+	   FROM republicacoes rep
+	   INNER JOIN publicacoes p ON p.id = rep.publicacao_id
+	   INNER JOIN usuarios u ON u.id = p.autor_id
+	   INNER JOIN usuarios ur ON ur.id = rep.usuario_id
+	   WHERE p.status = 'publicada'
+	     AND (rep.usuario_id = ?
+	      OR rep.usuario_id IN (SELECT s.usuario_id FROM seguidores s WHERE s.seguidor_id = ?))
+	     AND `+publicacaoVisivel+` AND `+semSilenciado("p.autor_id")+`
+	     AND `+semSilenciado("rep.usuario_id")+`
+	   ORDER BY momento DESC, id DESC`, args...)
 	if erro != nil {
 		return nil, erro
 	}
 
+	defer linhas.Close()
+
+	publicacoes := []models.Publicacao{}
+
 	for linhas.Next() {
-		var publicacao models.Publicacao
-		if erro := linhas.Scan(&publicacao.Id, &publicacao.Titulo, &publicacao.Conteudo,
-			&publicacao.AutorId, &publicacao.CriadaEm, &publicacao.Curtidas,
-			&publicacao.AutorNick); erro != nil {
+		var (
+			republicadorId   sql.NullInt64
+			republicadorNick sql.NullString
+			republicadorFoto sql.NullString
+			republicadaEm    sql.NullTime
+			momento          time.Time
+		)
+
+		publicacao, erro := scanPublicacao(linhas, &republicadorId, &republicadorNick, &republicadorFoto, &republicadaEm, &momento)
+		if erro != nil {
 			return nil, erro
 		}
+
+		if republicadorId.Valid {
+			publicacao.RepublicadaPor = &models.Republicacao{
+				UsuarioId:     uint64(republicadorId.Int64),
+				UsuarioNick:   republicadorNick.String,
+				UsuarioAvatar: ImagemPerfil(republicadorFoto, midia.TamanhosAvatar),
+				CriadaEm:      republicadaEm.Time,
+			}
+		}
 		publicacoes = append(publicacoes, publicacao)
 	}
 
-	return publicacoes, nil
+	if erro := linhas.Err(); erro != nil {
+		return nil, erro
+	}
+
+	return publicacoes, completarPublicacoes(repository.db, publicacoes, usuarioId)
 }
 
 func (repository Publicacoes) PublicacaoUsuarioExiste(publicacaoId uint64, usuarioId uint64) (bool, error) {
@@ -113,18 +402,113 @@ func (repository Publicacoes) PublicacaoUsuarioExiste(publicacaoId uint64, usuar
 
 }
 
-func (repository Publicacoes) Atualizar(publicacao models.Publicacao) error {
-	statement, erro := repository.db.Prepare("update publicacoes set titulo = ?, conteudo = ? where id = ?")
+// PublicacaoExiste informa se há uma publicação publicada com o id, de qualquer autor.
+// Rascunhos e agendadas não contam, para não serem respondidos, citados ou curtidos
+func (repository Publicacoes) PublicacaoExiste(publicacaoId uint64) (bool, error) {
+	var exists bool
+	err := repository.db.QueryRow("select 1 from publicacoes where id = ? and status = 'publicada'", publicacaoId).Scan(&exists)
+
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
 
+// Atualizar troca título e conteúdo da publicação, guardando o texto anterior como revisão.
+// Quando nada muda, nenhuma revisão é criada
+func (repository Publicacoes) Atualizar(publicacao models.Publicacao) error {
+	tx, erro := repository.db.Begin()
 	if erro != nil {
 		return erro
 	}
-	defer statement.Close()
+	defer tx.Rollback()
 
-	if _, erro := statement.Exec(publicacao.Titulo, publicacao.Conteudo, publicacao.Id); erro != nil {
+	var (
+		tituloAtual, conteudoAtual string
+		edicoes                    uint64
+		versaoDesde                time.Time
+	)
+	if erro := tx.QueryRow(`select titulo, conteudo, edicoes, coalesce(editadaEm, criadaEm)
+		from publicacoes where id = ? for update`, publicacao.Id).Scan(
+		&tituloAtual, &conteudoAtual, &edicoes, &versaoDesde); erro != nil {
 		return erro
 	}
-	return nil
+
+	if tituloAtual == publicacao.Titulo && conteudoAtual == publicacao.Conteudo {
+		return tx.Commit()
+	}
+
+	if _, erro := tx.Exec(`insert into revisoes (publicacao_id, numero, titulo, conteudo, criadaEm)
+		values (?, ?, ?, ?, ?)`, publicacao.Id, edicoes+1, tituloAtual, conteudoAtual, versaoDesde); erro != nil {
+		return erro
+	}
+
+	if _, erro := tx.Exec(`update publicacoes set titulo = ?, conteudo = ?,
+		editadaEm = current_timestamp(), edicoes = edicoes + 1 where id = ?`,
+		publicacao.Titulo, publicacao.Conteudo, publicacao.Id); erro != nil {
+		return erro
+	}
+
+	if erro := salvarTags(tx, publicacao.Id, publicacao.Tags); erro != nil {
+		return erro
+	}
+
+	if erro := salvarMencoes(tx, publicacao.Id, publicacao.Mencoes); erro != nil {
+		return erro
+	}
+
+	if erro := notificarMencoes(tx, publicacao.Id); erro != nil {
+		return erro
+	}
+
+	return tx.Commit()
+}
+
+// BuscarRevisoes retorna todas as versões da publicação, da original até a atual,
+// cada uma com o diff em relação à anterior
+func (repository Publicacoes) BuscarRevisoes(publicacao models.Publicacao) ([]models.Revisao, error) {
+	linhas, erro := repository.db.Query(`select numero, titulo, conteudo, criadaEm
+		from revisoes where publicacao_id = ? order by numero`, publicacao.Id)
+	if erro != nil {
+		return nil, erro
+	}
+
+	defer linhas.Close()
+
+	revisoes := []models.Revisao{}
+
+	for linhas.Next() {
+		var revisao models.Revisao
+		if erro := linhas.Scan(&revisao.Numero, &revisao.Titulo, &revisao.Conteudo, &revisao.CriadaEm); erro != nil {
+			return nil, erro
+		}
+		revisoes = append(revisoes, revisao)
+	}
+
+	if erro := linhas.Err(); erro != nil {
+		return nil, erro
+	}
+
+	atual := models.Revisao{
+		Numero:   uint64(len(revisoes)) + 1,
+		Titulo:   publicacao.Titulo,
+		Conteudo: publicacao.Conteudo,
+		CriadaEm: publicacao.CriadaEm,
+	}
+	if publicacao.EditadaEm != nil {
+		atual.CriadaEm = *publicacao.EditadaEm
+	}
+	revisoes = append(revisoes, atual)
+
+	for i := 1; i < len(revisoes); i++ {
+		revisoes[i].DiffTitulo = diff.Palavras(revisoes[i-1].Titulo, revisoes[i].Titulo)
+		revisoes[i].DiffConteudo = diff.Palavras(revisoes[i-1].Conteudo, revisoes[i].Conteudo)
+	}
+
+	return revisoes, nil
 }
 
 func (repository Publicacoes) Deletar(publicacaoId uint64) error {
@@ -141,38 +525,67 @@ func (repository Publicacoes) Deletar(publicacaoId uint64) error {
 	return nil
 }
 
-func (repository Publicacoes) BuscarPublicacoesUsuario(usuarioId uint64) ([]models.Publicacao, error) {
-	publicacoes := []models.Publicacao{}
-	linhas, erro := repository.db.Query(`SELECT p.id, p.titulo, p.conteudo, p.autor_id, p.criadaEm,
-       COUNT(c.publicacao_id) AS curtidas,
-       u.nick FROM publicacoes p
+// BuscarPublicacoesUsuario lista as publicações do perfil que o visitante pode ver, com a fixada primeiro
+func (repository Publicacoes) BuscarPublicacoesUsuario(usuarioId uint64, visitanteId uint64) ([]models.Publicacao, error) {
+	linhas, erro := repository.db.Query(`SELECT `+colunasPublicacao+`,
+	       COALESCE(p.id = u.publicacao_fixada, FALSE) AS fixada
+	   FROM publicacoes p
 	   INNER JOIN usuarios u ON u.id = p.autor_id
-	   LEFT JOIN curtidas c ON c.publicacao_id = p.id
-	   WHERE u.id = ?
-	   GROUP BY p.id, p.titulo, p.conteudo, p.autor_id, p.criadaEm, u.nick
-	   ORDER BY 1 DESC`, usuarioId)
+	   WHERE p.autor_id = ? AND p.status = 'publicada'
+	     AND `+publicacaoVisivel+`
+	   ORDER BY fixada DESC, p.criadaEm DESC, p.id DESC`, append([]any{usuarioId}, argsVisivel(visitanteId)...)...)
 	if erro != nil {
 		return nil, erro
 	}
+	defer linhas.Close()
 
+	publicacoes := []models.Publicacao{}
 	for linhas.Next() {
-		var publicacao models.Publicacao
-		if erro := linhas.Scan(&publicacao.Id, &publicacao.Titulo, &publicacao.Conteudo,
-			&publicacao.AutorId, &publicacao.CriadaEm, &publicacao.Curtidas,
-			&publicacao.AutorNick); erro != nil {
+		var fixada bool
+		publicacao, erro := scanPublicacao(linhas, &fixada)
+		if erro != nil {
 			return nil, erro
 		}
+		publicacao.Fixada = fixada
 		publicacoes = append(publicacoes, publicacao)
 	}
 
-	return publicacoes, nil
+	if erro := linhas.Err(); erro != nil {
+		return nil, erro
+	}
+
+	return publicacoes, completarPublicacoes(repository.db, publicacoes, visitanteId)
+}
+
+// PublicacaoPublicadaDoUsuario informa se a publicação é do usuário e já foi publicada,
+// condição para fixá-la no perfil
+func (repository Publicacoes) PublicacaoPublicadaDoUsuario(publicacaoId uint64, usuarioId uint64) (bool, error) {
+	var existe bool
+	erro := repository.db.QueryRow("select 1 from publicacoes where id = ? and autor_id = ? and status = 'publicada'",
+		publicacaoId, usuarioId).Scan(&existe)
+
+	if erro == sql.ErrNoRows {
+		return false, nil
+	}
+	if erro != nil {
+		return false, erro
+	}
+	return true, nil
 }
 
+// CurtiPublicacao alterna a curtida do usuário. Curtir substitui a reação anterior do usuário na publicação
 func (repository Publicacoes) CurtiPublicacao(publicacaoId uint64, usuarioId uint64) error {
-	resultado, erro := repository.db.Exec(
-		"delete from curtidas where usuario_id = ? and publicacao_id = ?",
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return erro
+	}
+	defer tx.Rollback()
+
+	resultado, erro := tx.Exec(
+		"delete from reacoes where usuario_id = ? and publicacao_id = ? and tipo = ?",
 		usuarioId,
 		publicacaoId,
+		models.ReacaoCurtir,
 	)
 	if erro != nil {
 		return erro
@@ -184,13 +597,270 @@ func (repository Publicacoes) CurtiPublicacao(publicacaoId uint64, usuarioId uin
 	}
 
 	if linhasAfetadas == 0 {
-		_, erro = repository.db.Exec(
-			"insert into curtidas (usuario_id, publicacao_id) values (?, ?)",
-			usuarioId,
-			publicacaoId,
-		)
+		tx.Rollback()
+		return NewReacoesRepo(repository.db).Reagir(publicacaoId, usuarioId, models.ReacaoCurtir)
+	}
+
+	if erro := desfazerNotificacaoAutor(tx, models.NotificacaoCurtida, publicacaoId, usuarioId); erro != nil {
 		return erro
 	}
 
-	return nil
+	if erro := registrarMudancaReacoes(tx, publicacaoId); erro != nil {
+		return erro
+	}
+
+	return tx.Commit()
+}
+
+// BuscarAncestrais retorna a cadeia de publicações respondidas que o visitante pode ver, da raiz da
+// conversa até a publicação imediatamente acima da informada
+func (repository Publicacoes) BuscarAncestrais(publicacaoId uint64, visitanteId uint64) ([]models.Publicacao, error) {
+	return buscarListaPublicacoes(repository.db, visitanteId, `WITH RECURSIVE ancestrais (id, em_resposta_a, nivel) AS (
+	       SELECT id, em_resposta_a, 0 FROM publicacoes WHERE id = ?
+	       UNION ALL
+	       SELECT pa.id, pa.em_resposta_a, a.nivel + 1 FROM publicacoes pa
+	       INNER JOIN ancestrais a ON pa.id = a.em_resposta_a
+	   )
+	   `+selectPublicacao+`
+	   INNER JOIN ancestrais a ON a.id = p.id
+	   WHERE a.nivel > 0 AND p.status = 'publicada' AND `+publicacaoVisivel+`
+	   ORDER BY a.nivel DESC`, append([]any{publicacaoId}, argsVisivel(visitanteId)...)...)
+}
+
+// BuscarRespostas monta a árvore de respostas de uma publicação até a profundidade informada.
+// Uma resposta que o visitante não pode ver some junto com as respostas a ela
+func (repository Publicacoes) BuscarRespostas(publicacaoId uint64, profundidade uint64, visitanteId uint64) ([]models.RespostaConversa, error) {
+	publicacoes, erro := buscarListaPublicacoes(repository.db, visitanteId, `WITH RECURSIVE respostas (id, nivel) AS (
+	       SELECT id, 1 FROM publicacoes WHERE em_resposta_a = ?
+	       UNION ALL
+	       SELECT pr.id, arvore.nivel + 1 FROM publicacoes pr
+	       INNER JOIN respostas arvore ON pr.em_resposta_a = arvore.id
+	       WHERE arvore.nivel < ?
+	   )
+	   `+selectPublicacao+`
+	   INNER JOIN respostas arvore ON arvore.id = p.id
+	   WHERE p.status = 'publicada' AND `+publicacaoVisivel+`
+	   ORDER BY p.id`, append([]any{publicacaoId, profundidade}, argsVisivel(visitanteId)...)...)
+	if erro != nil {
+		return nil, erro
+	}
+
+	filhos := make(map[uint64][]models.Publicacao)
+	for _, publicacao := range publicacoes {
+		filhos[*publicacao.EmRespostaA] = append(filhos[*publicacao.EmRespostaA], publicacao)
+	}
+
+	return montarRespostas(filhos, publicacaoId), nil
+}
+
+func montarRespostas(filhos map[uint64][]models.Publicacao, paiId uint64) []models.RespostaConversa {
+	respostas := []models.RespostaConversa{}
+	for _, publicacao := range filhos[paiId] {
+		respostas = append(respostas, models.RespostaConversa{
+			Publicacao: publicacao,
+			Respostas:  montarRespostas(filhos, publicacao.Id),
+		})
+	}
+	return respostas
+}
+
+// Curtir grava a curtida do usuário, no lugar de uma reação de outro tipo que ele tenha feito.
+// Curtir de novo não tem efeito
+func (repository Publicacoes) Curtir(publicacaoId uint64, usuarioId uint64) error {
+	return NewReacoesRepo(repository.db).Reagir(publicacaoId, usuarioId, models.ReacaoCurtir)
+}
+
+// Descurtir remove a curtida do usuário, sem mexer em reações de outro tipo
+func (repository Publicacoes) Descurtir(publicacaoId uint64, usuarioId uint64) error {
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return erro
+	}
+	defer tx.Rollback()
+
+	if _, erro := tx.Exec(
+		"delete from reacoes where usuario_id = ? and publicacao_id = ? and tipo = ?",
+		usuarioId,
+		publicacaoId,
+		models.ReacaoCurtir,
+	); erro != nil {
+		return erro
+	}
+
+	if erro := desfazerNotificacaoAutor(tx, models.NotificacaoCurtida, publicacaoId, usuarioId); erro != nil {
+		return erro
+	}
+
+	if erro := registrarMudancaReacoes(tx, publicacaoId); erro != nil {
+		return erro
+	}
+
+	return tx.Commit()
+}
+
+// EstadoCurtida informa se o usuário curte a publicação e quantas curtidas ela tem
+func (repository Publicacoes) EstadoCurtida(publicacaoId uint64, usuarioId uint64) (models.EstadoCurtida, error) {
+	estado := models.EstadoCurtida{PublicacaoId: publicacaoId}
+	erro := repository.db.QueryRow(`SELECT
+	       COUNT(*),
+	       COALESCE(SUM(usuario_id = ?), 0) > 0
+	   FROM reacoes WHERE publicacao_id = ? AND tipo = ?`,
+		usuarioId, publicacaoId, models.ReacaoCurtir).Scan(&estado.Curtidas, &estado.Curtido)
+	return estado, erro
+}
+
+// Republicar registra a republicação. Republicar de novo a mesma publicação não tem efeito
+func (repository Publicacoes) Republicar(publicacaoId uint64, usuarioId uint64) error {
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return erro
+	}
+	defer tx.Rollback()
+
+	resultado, erro := tx.Exec(
+		"insert ignore into republicacoes (usuario_id, publicacao_id) values (?, ?)",
+		usuarioId,
+		publicacaoId,
+	)
+	if erro != nil {
+		return erro
+	}
+
+	if inseridas, erro := resultado.RowsAffected(); erro != nil || inseridas == 0 {
+		return erro
+	}
+
+	// Para o feed, a republicação é um item novo de quem republicou
+	if erro := registrarEvento(tx, models.EventoPublicacao, nil, &usuarioId,
+		models.DadosEventoPublicacao{PublicacaoId: publicacaoId, RepublicadaPor: &usuarioId}); erro != nil {
+		return erro
+	}
+
+	return tx.Commit()
+}
+
+func (repository Publicacoes) DesfazerRepublicacao(publicacaoId uint64, usuarioId uint64) error {
+	_, erro := repository.db.Exec(
+		"delete from republicacoes where usuario_id = ? and publicacao_id = ?",
+		usuarioId,
+		publicacaoId,
+	)
+	return erro
+}
+
+// BuscarRascunhos lista os rascunhos e as publicações agendadas do usuário,
+// das editadas mais recentemente para as mais antigas
+func (repository Publicacoes) BuscarRascunhos(usuarioId uint64, paginacao models.Paginacao) ([]models.Publicacao, error) {
+	return buscarListaPublicacoes(repository.db, usuarioId, selectPublicacao+`
+	   WHERE p.autor_id = ? AND p.status <> 'publicada'
+	   ORDER BY COALESCE(p.editadaEm, p.criadaEm) DESC, p.id DESC
+	   LIMIT ? OFFSET ?`, usuarioId, paginacao.Limite, paginacao.Offset())
+}
+
+// BuscarRascunho retorna um rascunho ou agendada do usuário ou sql.ErrNoRows
+func (repository Publicacoes) BuscarRascunho(publicacaoId uint64, usuarioId uint64) (models.Publicacao, error) {
+	linha := repository.db.QueryRow(selectPublicacao+`
+	   WHERE p.id = ? AND p.autor_id = ? AND p.status <> 'publicada'`, publicacaoId, usuarioId)
+
+	return scanPublicacao(linha)
+}
+
+// AtualizarRascunho grava texto, status, data de publicação e visibilidade de um rascunho sem criar revisões.
+// Se o status passar a publicada, a data de criação vira o momento da publicação
+func (repository Publicacoes) AtualizarRascunho(publicacao models.Publicacao) error {
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return erro
+	}
+	defer tx.Rollback()
+
+	resultado, erro := tx.Exec(`update publicacoes set titulo = ?, conteudo = ?, status = ?, publicarEm = ?,
+		visibilidade = ?, criadaEm = if(? = 'publicada', current_timestamp(), criadaEm)
+		where id = ? and status <> 'publicada'`,
+		publicacao.Titulo, publicacao.Conteudo, publicacao.Status, publicacao.PublicarEm,
+		publicacao.Visibilidade, publicacao.Status, publicacao.Id)
+	if erro != nil {
+		return erro
+	}
+
+	if alteradas, erro := resultado.RowsAffected(); erro != nil || alteradas == 0 {
+		return erro
+	}
+
+	if erro := salvarTags(tx, publicacao.Id, publicacao.Tags); erro != nil {
+		return erro
+	}
+
+	if erro := salvarMencoes(tx, publicacao.Id, publicacao.Mencoes); erro != nil {
+		return erro
+	}
+
+	if erro := notificarMencoes(tx, publicacao.Id); erro != nil {
+		return erro
+	}
+
+	if publicacao.Status == models.StatusPublicada {
+		if erro := registrarEvento(tx, models.EventoPublicacao, nil, &publicacao.AutorId,
+			models.DadosEventoPublicacao{PublicacaoId: publicacao.Id}); erro != nil {
+			return erro
+		}
+	}
+
+	return tx.Commit()
+}
+
+// PublicarAgendadas publica as agendadas cujo horário já passou e retorna quantas foram publicadas.
+// As linhas ficam travadas na transação, então pode rodar em mais de uma instância da API ao
+// mesmo tempo: a segunda espera e já não encontra as publicações como agendadas
+func (repository Publicacoes) PublicarAgendadas(agora time.Time) (int64, error) {
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return 0, erro
+	}
+	defer tx.Rollback()
+
+	linhas, erro := tx.Query(`select id, autor_id from publicacoes
+		where status = 'agendada' and publicarEm <= ? for update`, agora)
+	if erro != nil {
+		return 0, erro
+	}
+
+	ids := []any{}
+	autores := []uint64{}
+	for linhas.Next() {
+		var id, autorId uint64
+		if erro := linhas.Scan(&id, &autorId); erro != nil {
+			linhas.Close()
+			return 0, erro
+		}
+		ids = append(ids, id)
+		autores = append(autores, autorId)
+	}
+	linhas.Close()
+	if erro := linhas.Err(); erro != nil {
+		return 0, erro
+	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if _, erro := tx.Exec(`update publicacoes set status = 'publicada', criadaEm = publicarEm
+		where id in (`+placeholders(len(ids))+`)`, ids...); erro != nil {
+		return 0, erro
+	}
+
+	// As menções foram gravadas quando a publicação foi agendada, mas só avisam agora
+	if erro := notificarMencoes(tx, ids...); erro != nil {
+		return 0, erro
+	}
+
+	for i, id := range ids {
+		if erro := registrarEvento(tx, models.EventoPublicacao, nil, &autores[i],
+			models.DadosEventoPublicacao{PublicacaoId: id.(uint64)}); erro != nil {
+			return 0, erro
+		}
+	}
+
+	return int64(len(ids)), tx.Commit()
 }
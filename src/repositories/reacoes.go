@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"api/src/midia"
+	"api/src/models"
+	"database/sql"
+)
+
+type Reacoes struct {
+	db *sql.DB
+}
+
+// Cria instancia de reacoes com banco para realizar as funções
+// Pode ser passado qualquer banco
+func NewReacoesRepo(db *sql.DB) *Reacoes {
+	return &Reacoes{db}
+}
+
+// Reagir grava a reação do usuário na publicação, trocando a anterior caso exista.
+// Só uma reação nova ou de outro tipo é gravada, com o momento atual, e notifica o autor; repetir a
+// mesma reação não tem efeito.
+// Retorna ErrBloqueado se o usuário e o autor têm bloqueio entre si
+func (repository Reacoes) Reagir(publicacaoId uint64, usuarioId uint64, tipo string) error {
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return erro
+	}
+	defer tx.Rollback()
+
+	var autorId uint64
+	if erro := tx.QueryRow("select autor_id from publicacoes where id = ?", publicacaoId).Scan(&autorId); erro != nil {
+		return erro
+	}
+	if bloqueio, erro := bloqueados(tx, usuarioId, autorId); erro != nil || bloqueio {
+		if erro == nil {
+			erro = ErrBloqueado
+		}
+		return erro
+	}
+
+	var anterior string
+	erro = tx.QueryRow("select tipo from reacoes where usuario_id = ? and publicacao_id = ? for update",
+		usuarioId, publicacaoId).Scan(&anterior)
+	if erro != nil && erro != sql.ErrNoRows {
+		return erro
+	}
+
+	// Repetir a mesma reação não mexe em nada, nem no momento em que ela foi feita
+	if anterior == tipo {
+		return tx.Commit()
+	}
+
+	if _, erro := tx.Exec(`insert into reacoes (usuario_id, publicacao_id, tipo) values (?, ?, ?)
+		on duplicate key update tipo = values(tipo), criadaEm = current_timestamp()`,
+		usuarioId,
+		publicacaoId,
+		tipo,
+	); erro != nil {
+		return erro
+	}
+
+	if anterior != "" {
+		if erro := desfazerNotificacaoAutor(tx, tipoNotificacaoReacao(anterior), publicacaoId, usuarioId); erro != nil {
+			return erro
+		}
+	}
+	if erro := notificarAutor(tx, tipoNotificacaoReacao(tipo), publicacaoId, usuarioId); erro != nil {
+		return erro
+	}
+	if erro := registrarMudancaReacoes(tx, publicacaoId); erro != nil {
+		return erro
+	}
+
+	return tx.Commit()
+}
+
+func (repository Reacoes) Remover(publicacaoId uint64, usuarioId uint64) error {
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return erro
+	}
+	defer tx.Rollback()
+
+	if _, erro := tx.Exec(
+		"delete from reacoes where usuario_id = ? and publicacao_id = ?",
+		usuarioId,
+		publicacaoId,
+	); erro != nil {
+		return erro
+	}
+
+	for _, tipo := range []string{models.NotificacaoCurtida, models.NotificacaoReacao} {
+		if erro := desfazerNotificacaoAutor(tx, tipo, publicacaoId, usuarioId); erro != nil {
+			return erro
+		}
+	}
+
+	if erro := registrarMudancaReacoes(tx, publicacaoId); erro != nil {
+		return erro
+	}
+
+	return tx.Commit()
+}
+
+// tipoNotificacaoReacao separa as curtidas das demais reações nas notificações
+func tipoNotificacaoReacao(tipo string) string {
+	if tipo == models.ReacaoCurtir {
+		return models.NotificacaoCurtida
+	}
+	return models.NotificacaoReacao
+}
+
+// BuscarReacaoUsuario retorna a reação do usuário na publicação ou sql.ErrNoRows se não houver
+func (repository Reacoes) BuscarReacaoUsuario(publicacaoId uint64, usuarioId uint64) (models.Reacao, error) {
+	reacao := models.Reacao{UsuarioId: usuarioId}
+	erro := repository.db.QueryRow(`SELECT tipo, criadaEm FROM reacoes
+	   WHERE publicacao_id = ? AND usuario_id = ?`, publicacaoId, usuarioId).Scan(&reacao.Tipo, &reacao.CriadaEm)
+	return reacao, erro
+}
+
+// BuscarPorPublicacao lista quem reagiu à publicação, das reações mais recentes para as mais antigas.
+// Quando tipo é vazio, traz reações de todos os tipos
+func (repository Reacoes) BuscarPorPublicacao(publicacaoId uint64, tipo string, paginacao models.Paginacao) ([]models.Reacao, error) {
+	linhas, erro := repository.db.Query(`SELECT r.usuario_id, u.nick, u.avatar, r.tipo, r.criadaEm
+	   FROM reacoes r
+	   INNER JOIN usuarios u ON u.id = r.usuario_id
+	   WHERE r.publicacao_id = ? AND (? = '' OR r.tipo = ?)
+	   ORDER BY r.criadaEm DESC, r.usuario_id
+	   LIMIT ? OFFSET ?`, publicacaoId, tipo, tipo, paginacao.Limite, paginacao.Offset())
+	if erro != nil {
+		return nil, erro
+	}
+
+	defer linhas.Close()
+
+	reacoes := []models.Reacao{}
+
+	for linhas.Next() {
+		var reacao models.Reacao
+		var usuarioAvatar sql.NullString
+		if erro := linhas.Scan(&reacao.UsuarioId, &reacao.UsuarioNick, &usuarioAvatar, &reacao.Tipo, &reacao.CriadaEm); erro != nil {
+			return nil, erro
+		}
+		reacao.UsuarioAvatar = ImagemPerfil(usuarioAvatar, midia.TamanhosAvatar)
+		reacoes = append(reacoes, reacao)
+	}
+
+	return reacoes, linhas.Err()
+}
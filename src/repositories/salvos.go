@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"api/src/models"
+	"database/sql"
+)
+
+type Salvos struct {
+	db *sql.DB
+}
+
+// Cria instancia de salvos com banco para realizar as funções
+func NewSalvosRepo(db *sql.DB) *Salvos {
+	return &Salvos{db}
+}
+
+// Salvar guarda a publicação entre as salvas do usuário, na coleção informada ou fora de coleções.
+// Salvar de novo só troca a coleção
+func (repository Salvos) Salvar(usuarioId uint64, publicacaoId uint64, colecaoId *uint64) error {
+	_, erro := repository.db.Exec(`insert into salvos (usuario_id, publicacao_id, colecao_id) values (?, ?, ?)
+		on duplicate key update colecao_id = values(colecao_id)`, usuarioId, publicacaoId, colecaoId)
+	return erro
+}
+
+// RemoverSalvo tira a publicação das salvas do usuário. Remover de novo não tem efeito
+func (repository Salvos) RemoverSalvo(usuarioId uint64, publicacaoId uint64) error {
+	_, erro := repository.db.Exec("delete from salvos where usuario_id = ? and publicacao_id = ?", usuarioId, publicacaoId)
+	return erro
+}
+
+// BuscarSalvos lista as publicações salvas pelo usuário, das salvas mais recentemente para as mais antigas.
+// Com colecaoId, só as daquela coleção. Publicações que o usuário deixou de poder ver ficam de fora
+func (repository Salvos) BuscarSalvos(usuarioId uint64, colecaoId *uint64, paginacao models.Paginacao) ([]models.Publicacao, error) {
+	args := append([]any{usuarioId, colecaoId, colecaoId}, argsVisivel(usuarioId)...)
+	args = append(args, paginacao.Limite, paginacao.Offset())
+
+	return buscarListaPublicacoes(repository.db, usuarioId, selectPublicacao+`
+	   INNER JOIN salvos s ON s.publicacao_id = p.id
+	   WHERE s.usuario_id = ? AND (? IS NULL OR s.colecao_id = ?)
+	     AND p.status = 'publicada' AND `+publicacaoVisivel+`
+	   ORDER BY s.salvoEm DESC, p.id DESC
+	   LIMIT ? OFFSET ?`, args...)
+}
+
+// BuscarColecoes lista as coleções do usuário em ordem alfabética, com quantas publicações cada uma tem
+func (repository Salvos) BuscarColecoes(usuarioId uint64) ([]models.Colecao, error) {
+	linhas, erro := repository.db.Query(`select c.id, c.nome, c.criadaEm,
+		       (select count(*) from salvos s where s.colecao_id = c.id)
+		from colecoes c
+		where c.usuario_id = ?
+		order by c.nome, c.id`, usuarioId)
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	colecoes := []models.Colecao{}
+	for linhas.Next() {
+		var colecao models.Colecao
+		if erro := linhas.Scan(&colecao.Id, &colecao.Nome, &colecao.CriadaEm, &colecao.Salvos); erro != nil {
+			return nil, erro
+		}
+		colecoes = append(colecoes, colecao)
+	}
+
+	return colecoes, linhas.Err()
+}
+
+// BuscarColecao retorna a coleção do usuário ou sql.ErrNoRows
+func (repository Salvos) BuscarColecao(colecaoId uint64, usuarioId uint64) (models.Colecao, error) {
+	var colecao models.Colecao
+	erro := repository.db.QueryRow(`select c.id, c.nome, c.criadaEm,
+		       (select count(*) from salvos s where s.colecao_id = c.id)
+		from colecoes c
+		where c.id = ? and c.usuario_id = ?`, colecaoId, usuarioId).Scan(
+		&colecao.Id, &colecao.Nome, &colecao.CriadaEm, &colecao.Salvos)
+	return colecao, erro
+}
+
+// NomeEmUso informa se o usuário já tem outra coleção com o nome, ignorando a coleção exceto
+func (repository Salvos) NomeEmUso(usuarioId uint64, nome string, exceto uint64) (bool, error) {
+	return existeLinha(repository.db, "select 1 from colecoes where usuario_id = ? and nome = ? and id <> ?",
+		usuarioId, nome, exceto)
+}
+
+// CriarColecao cria uma coleção vazia e retorna o id dela
+func (repository Salvos) CriarColecao(usuarioId uint64, nome string) (uint64, error) {
+	resultado, erro := repository.db.Exec("insert into colecoes (usuario_id, nome) values (?, ?)", usuarioId, nome)
+	if erro != nil {
+		return 0, erro
+	}
+
+	id, erro := resultado.LastInsertId()
+	return uint64(id), erro
+}
+
+// RenomearColecao troca o nome de uma coleção do usuário
+func (repository Salvos) RenomearColecao(colecaoId uint64, usuarioId uint64, nome string) error {
+	_, erro := repository.db.Exec("update colecoes set nome = ? where id = ? and usuario_id = ?", nome, colecaoId, usuarioId)
+	return erro
+}
+
+// DeletarColecao apaga a coleção. As publicações dela continuam salvas, fora de coleções
+func (repository Salvos) DeletarColecao(colecaoId uint64, usuarioId uint64) error {
+	_, erro := repository.db.Exec("delete from colecoes where id = ? and usuario_id = ?", colecaoId, usuarioId)
+	return erro
+}
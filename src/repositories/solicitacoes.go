@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"api/src/midia"
+	"api/src/models"
+	"database/sql"
+)
+
+// PerfilVisivel informa se o visitante pode ver as publicações e os seguidores do usuário:
+// contas públicas para todos, privadas só para o dono e para seguidores aceitos.
+// Retorna sql.ErrNoRows se o usuário não existe
+func (repository Usuarios) PerfilVisivel(usuarioId uint64, visitanteId uint64) (bool, error) {
+	var visivel bool
+	erro := repository.db.QueryRow(`select u.id = ? or not u.privado
+		    or exists (select 1 from seguidores s where s.usuario_id = u.id and s.seguidor_id = ?)
+		from usuarios u where u.id = ?`,
+		visitanteId, visitanteId, usuarioId).Scan(&visivel)
+	return visivel, erro
+}
+
+// BuscarSolicitacoes lista quem pediu para seguir o usuário, das solicitações mais antigas para as mais recentes
+func (repository Usuarios) BuscarSolicitacoes(usuarioId uint64, paginacao models.Paginacao) ([]models.Usuario, error) {
+	linhas, erro := repository.db.Query(`select u.id, u.nome, u.nick, u.avatar from usuarios u
+		inner join solicitacoes_seguir ss on ss.solicitante_id = u.id
+		where ss.usuario_id = ?
+		order by ss.criadaEm, u.id
+		limit ? offset ?`, usuarioId, paginacao.Limite, paginacao.Offset())
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	usuarios := []models.Usuario{}
+	for linhas.Next() {
+		var usuario models.Usuario
+		var avatar sql.NullString
+		if erro := linhas.Scan(&usuario.Id, &usuario.Nome, &usuario.Nick, &avatar); erro != nil {
+			return nil, erro
+		}
+		usuario.Avatar = ImagemPerfil(avatar, midia.TamanhosAvatar)
+		usuarios = append(usuarios, usuario)
+	}
+
+	return usuarios, linhas.Err()
+}
+
+// AceitarSolicitacao transforma a solicitação em follow. Retorna false se não houver solicitação
+func (repository Usuarios) AceitarSolicitacao(usuarioId uint64, solicitanteId uint64) (bool, error) {
+	return repository.responderSolicitacao(usuarioId, solicitanteId, true)
+}
+
+// RecusarSolicitacao descarta a solicitação sem avisar quem pediu. Retorna false se não houver solicitação
+func (repository Usuarios) RecusarSolicitacao(usuarioId uint64, solicitanteId uint64) (bool, error) {
+	return repository.responderSolicitacao(usuarioId, solicitanteId, false)
+}
+
+func (repository Usuarios) responderSolicitacao(usuarioId uint64, solicitanteId uint64, aceitar bool) (bool, error) {
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return false, erro
+	}
+	defer tx.Rollback()
+
+	resultado, erro := tx.Exec("delete from solicitacoes_seguir where usuario_id = ? and solicitante_id = ?",
+		usuarioId, solicitanteId)
+	if erro != nil {
+		return false, erro
+	}
+
+	if removidas, erro := resultado.RowsAffected(); erro != nil || removidas == 0 {
+		return false, erro
+	}
+
+	if erro := desfazerNotificacao(tx, models.NotificacaoSolicitacao, usuarioId, solicitanteId, nil); erro != nil {
+		return false, erro
+	}
+
+	if aceitar {
+		if _, erro := tx.Exec("insert ignore into seguidores (usuario_id, seguidor_id) values (?, ?)",
+			usuarioId, solicitanteId); erro != nil {
+			return false, erro
+		}
+	}
+
+	return true, tx.Commit()
+}
+
+// idsSolicitantes lista quem tem solicitação pendente para seguir o usuário
+func idsSolicitantes(exec executor, usuarioId uint64) ([]uint64, error) {
+	linhas, erro := exec.Query("select solicitante_id from solicitacoes_seguir where usuario_id = ?", usuarioId)
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	ids := []uint64{}
+	for linhas.Next() {
+		var id uint64
+		if erro := linhas.Scan(&id); erro != nil {
+			return nil, erro
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, linhas.Err()
+}
@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"api/src/midia"
+	"api/src/models"
+	"database/sql"
+	"time"
+)
+
+const (
+	// maximoSugestoes é quantas sugestões ficam guardadas para cada usuário a cada cálculo
+	maximoSugestoes = 50
+	// Pesos da pontuação: cada seguidor em comum, já seguir o usuário e a atividade recente,
+	// que conta pelo logaritmo para contas muito ativas não dominarem
+	pesoSeguidorEmComum = 1.0
+	pesoSegueVoce       = 3.0
+	pesoAtividade       = 0.5
+)
+
+// CalcularSugestoes refaz as sugestões de todos os usuários: amigos de amigos, pontuados pelos
+// seguidores em comum, e quem já segue o usuário sem ser seguido de volta, com um bônus para
+// quem publicou desde a data informada. Quem já é seguido, tem solicitação pendente, tem
+// bloqueio com o usuário ou foi dispensado fica de fora. Retorna quantas sugestões foram geradas
+func (repository Usuarios) CalcularSugestoes(atividadeDesde time.Time) (int64, error) {
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return 0, erro
+	}
+	defer tx.Rollback()
+
+	if _, erro := tx.Exec("delete from sugestoes"); erro != nil {
+		return 0, erro
+	}
+
+	resultado, erro := tx.Exec(`insert into sugestoes
+		(usuario_id, sugerido_id, seguidoresEmComum, segueVoce, publicacoesRecentes, pontuacao)
+		select usuario_id, sugerido_id, seguidoresEmComum, segueVoce, publicacoesRecentes, pontuacao
+		from (
+			select c.usuario_id, c.sugerido_id,
+			       sum(c.emComum) as seguidoresEmComum,
+			       max(c.segueVoce) as segueVoce,
+			       coalesce(max(a.publicacoes), 0) as publicacoesRecentes,
+			       sum(c.emComum) * ? + max(c.segueVoce) * ? + ln(1 + coalesce(max(a.publicacoes), 0)) * ? as pontuacao,
+			       row_number() over (partition by c.usuario_id
+			                          order by sum(c.emComum) * ? + max(c.segueVoce) * ?
+			                                   + ln(1 + coalesce(max(a.publicacoes), 0)) * ? desc,
+			                                   c.sugerido_id) as posicao
+			from (
+				select s1.seguidor_id as usuario_id, s2.usuario_id as sugerido_id, count(*) as emComum, 0 as segueVoce
+				from seguidores s1
+				inner join seguidores s2 on s2.seguidor_id = s1.usuario_id
+				where s2.usuario_id <> s1.seguidor_id
+				group by s1.seguidor_id, s2.usuario_id
+				union all
+				select s.usuario_id, s.seguidor_id, 0, 1 from seguidores s
+			) c
+			left join (
+				select autor_id, count(*) as publicacoes from publicacoes
+				where status = 'publicada' and criadaEm >= ?
+				group by autor_id
+			) a on a.autor_id = c.sugerido_id
+			where not exists (select 1 from seguidores s where s.usuario_id = c.sugerido_id and s.seguidor_id = c.usuario_id)
+			  and not exists (select 1 from solicitacoes_seguir so where so.usuario_id = c.sugerido_id and so.solicitante_id = c.usuario_id)
+			  and not exists (select 1 from sugestoes_dispensadas d where d.usuario_id = c.usuario_id and d.sugerido_id = c.sugerido_id)
+			  and not exists (select 1 from bloqueios b
+			                  where (b.usuario_id = c.usuario_id and b.bloqueado_id = c.sugerido_id)
+			                     or (b.usuario_id = c.sugerido_id and b.bloqueado_id = c.usuario_id))
+			group by c.usuario_id, c.sugerido_id
+		) pontuadas
+		where posicao <= ?`,
+		pesoSeguidorEmComum, pesoSegueVoce, pesoAtividade,
+		pesoSeguidorEmComum, pesoSegueVoce, pesoAtividade,
+		atividadeDesde, maximoSugestoes)
+	if erro != nil {
+		return 0, erro
+	}
+
+	geradas, erro := resultado.RowsAffected()
+	if erro != nil {
+		return 0, erro
+	}
+
+	return geradas, tx.Commit()
+}
+
+// BuscarSugestoes lista as sugestões calculadas para o usuário, das mais fortes para as mais fracas.
+// O que mudou desde o último cálculo (novos follows, bloqueios, dispensas) já é respeitado aqui
+func (repository Usuarios) BuscarSugestoes(usuarioId uint64, paginacao models.Paginacao) ([]models.Sugestao, error) {
+	linhas, erro := repository.db.Query(`select u.id, u.nome, u.nick, u.avatar, su.seguidoresEmComum, su.segueVoce
+		from sugestoes su
+		inner join usuarios u on u.id = su.sugerido_id
+		where su.usuario_id = ?
+		  and not exists (select 1 from seguidores s where s.usuario_id = su.sugerido_id and s.seguidor_id = su.usuario_id)
+		  and not exists (select 1 from solicitacoes_seguir so where so.usuario_id = su.sugerido_id and so.solicitante_id = su.usuario_id)
+		  and not exists (select 1 from sugestoes_dispensadas d where d.usuario_id = su.usuario_id and d.sugerido_id = su.sugerido_id)
+		  and `+semBloqueio("su.sugerido_id")+`
+		order by su.pontuacao desc, su.sugerido_id
+		limit ? offset ?`,
+		usuarioId, usuarioId, usuarioId, paginacao.Limite, paginacao.Offset())
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	sugestoes := []models.Sugestao{}
+	for linhas.Next() {
+		var sugestao models.Sugestao
+		var avatar sql.NullString
+		if erro := linhas.Scan(&sugestao.Usuario.Id, &sugestao.Usuario.Nome, &sugestao.Usuario.Nick, &avatar,
+			&sugestao.SeguidoresEmComum, &sugestao.SegueVoce); erro != nil {
+			return nil, erro
+		}
+		sugestao.Usuario.Avatar = ImagemPerfil(avatar, midia.TamanhosAvatar)
+		sugestoes = append(sugestoes, sugestao)
+	}
+
+	return sugestoes, linhas.Err()
+}
+
+// DispensarSugestao tira a conta das sugestões do usuário, agora e nos próximos cálculos
+func (repository Usuarios) DispensarSugestao(usuarioId, sugeridoId uint64) error {
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return erro
+	}
+	defer tx.Rollback()
+
+	if _, erro := tx.Exec("insert ignore into sugestoes_dispensadas (usuario_id, sugerido_id) values (?, ?)",
+		usuarioId, sugeridoId); erro != nil {
+		return erro
+	}
+
+	if _, erro := tx.Exec("delete from sugestoes where usuario_id = ? and sugerido_id = ?",
+		usuarioId, sugeridoId); erro != nil {
+		return erro
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"api/src/models"
+	"database/sql"
+	"math"
+	"strings"
+	"time"
+)
+
+type Tags struct {
+	db *sql.DB
+}
+
+// Cria instancia de tags com banco para realizar as funções
+func NewTagsRepo(db *sql.DB) *Tags {
+	return &Tags{db}
+}
+
+// salvarTags substitui as tags da publicação pelas informadas, criando as que ainda não existem
+func salvarTags(exec executor, publicacaoId uint64, tags []string) error {
+	if _, erro := exec.Exec("delete from publicacoes_tags where publicacao_id = ?", publicacaoId); erro != nil {
+		return erro
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	nomes := make([]any, 0, len(tags))
+	for _, tag := range tags {
+		nomes = append(nomes, tag)
+	}
+
+	valores := placeholders(len(tags))
+	valores = "(" + strings.ReplaceAll(valores, ",", "),(") + ")"
+	if _, erro := exec.Exec("insert ignore into tags (nome) values "+valores, nomes...); erro != nil {
+		return erro
+	}
+
+	_, erro := exec.Exec(`insert into publicacoes_tags (publicacao_id, tag_id)
+		select ?, id from tags where nome in (`+placeholders(len(tags))+`)`, append([]any{publicacaoId}, nomes...)...)
+	return erro
+}
+
+// carregarTags preenche as tags de cada publicação com uma única consulta
+func carregarTags(db *sql.DB, publicacoes []models.Publicacao) error {
+	if len(publicacoes) == 0 {
+		return nil
+	}
+
+	ids := idsPublicacoes(publicacoes)
+	linhas, erro := db.Query(`select pt.publicacao_id, t.nome from publicacoes_tags pt
+		inner join tags t on t.id = pt.tag_id
+		where pt.publicacao_id in (`+placeholders(len(ids))+`) order by t.nome`, ids...)
+	if erro != nil {
+		return erro
+	}
+	defer linhas.Close()
+
+	porPublicacao := make(map[uint64][]string)
+	for linhas.Next() {
+		var publicacaoId uint64
+		var tag string
+		if erro := linhas.Scan(&publicacaoId, &tag); erro != nil {
+			return erro
+		}
+		porPublicacao[publicacaoId] = append(porPublicacao[publicacaoId], tag)
+	}
+
+	if erro := linhas.Err(); erro != nil {
+		return erro
+	}
+
+	for i := range publicacoes {
+		publicacoes[i].Tags = porPublicacao[publicacoes[i].Id]
+	}
+
+	return nil
+}
+
+// BuscarPublicacoes traz as publicações publicadas com a tag que o visitante pode ver,
+// das mais recentes para as mais antigas
+func (repository Tags) BuscarPublicacoes(tag string, visitanteId uint64, paginacao models.Paginacao) ([]models.Publicacao, error) {
+	args := append([]any{tag}, argsVisivel(visitanteId)...)
+	return buscarListaPublicacoes(repository.db, visitanteId, selectPublicacao+`
+	   INNER JOIN publicacoes_tags pt ON pt.publicacao_id = p.id
+	   INNER JOIN tags t ON t.id = pt.tag_id
+	   WHERE t.nome = ? AND p.status = 'publicada' AND `+publicacaoVisivel+`
+	   ORDER BY p.criadaEm DESC, p.id DESC
+	   LIMIT ? OFFSET ?`, append(args, paginacao.Limite, paginacao.Offset())...)
+}
+
+// BuscarEmAlta calcula as tags em alta entre as publicações da janela informada.
+// Cada uso vale 1 no momento da publicação e perde metade do peso a cada meiaVida,
+// então uma tag muito usada agora passa à frente de uma que foi muito usada horas atrás
+func (repository Tags) BuscarEmAlta(agora time.Time, janela time.Duration, meiaVida time.Duration, limite uint64) ([]models.TagEmAlta, error) {
+	linhas, erro := repository.db.Query(`select t.nome, count(*) as publicacoes,
+		       sum(exp(? * timestampdiff(second, p.criadaEm, ?))) as pontuacao
+		from publicacoes_tags pt
+		inner join tags t on t.id = pt.tag_id
+		inner join publicacoes p on p.id = pt.publicacao_id
+		where p.status = 'publicada' and p.criadaEm > ? and p.criadaEm <= ?
+		group by t.id, t.nome
+		order by pontuacao desc, publicacoes desc, t.nome
+		limit ?`, -math.Ln2/meiaVida.Seconds(), agora, agora.Add(-janela), agora, limite)
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	tags := []models.TagEmAlta{}
+	for linhas.Next() {
+		var tag models.TagEmAlta
+		if erro := linhas.Scan(&tag.Tag, &tag.Publicacoes, &tag.Pontuacao); erro != nil {
+			return nil, erro
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, linhas.Err()
+}
@@ -1,9 +1,10 @@
 package repositories
 
 import (
+	"api/src/midia"
 	"api/src/models"
 	"database/sql"
-	"fmt"
+	"time"
 )
 
 type Usuarios struct {
@@ -38,34 +39,14 @@ func (repository Usuarios) Criar(usuario models.Usuario) (uint64, error) {
 	return uint64(idInserido), nil
 }
 
-func (repository Usuarios) Buscar(nomeOuNick string) ([]models.Usuario, error) {
-	nomeOuNick = fmt.Sprintf("%%%s%%", nomeOuNick)
-	linhas, erro := repository.db.Query("select id, nome, email, nick, criadoEm from usuarios where nome like ? or nick like ?", nomeOuNick, nomeOuNick)
-
-	if erro != nil {
-		return nil, erro
-	}
-
-	defer linhas.Close()
-
-	var usuarios []models.Usuario
-
-	for linhas.Next() {
-		var usuario models.Usuario
-		if erro := linhas.Scan(&usuario.Id, &usuario.Nome,
-			&usuario.Email, &usuario.Nick, &usuario.CriadoEm); erro != nil {
-			return nil, erro
-		}
-
-		usuarios = append(usuarios, usuario)
-	}
-
-	return usuarios, nil
-}
-
-func (repository Usuarios) BuscarPorId(id uint64) (models.Usuario, error) {
+// BuscarPorId retorna o usuário como o visitante o vê. Quem bloqueou o visitante não é encontrado
+func (repository Usuarios) BuscarPorId(id uint64, visitanteId uint64) (models.Usuario, error) {
 	usuario := models.Usuario{}
-	linha, erro := repository.db.Query("select id, nome, email,nick, criadoEm from usuarios where id = ?", id)
+	linha, erro := repository.db.Query(`select id, nome, email,nick, criadoEm, avatar, banner,
+		bio, localizacao, site, dataNascimento, dataNascimentoPublica, publicacao_fixada, mensagensApenasSeguidos, privado
+		from usuarios
+		where id = ?
+		  and not exists (select 1 from bloqueios b where b.usuario_id = usuarios.id and b.bloqueado_id = ?)`, id, visitanteId)
 	if erro != nil {
 		return usuario, erro
 	}
@@ -73,10 +54,20 @@ func (repository Usuarios) BuscarPorId(id uint64) (models.Usuario, error) {
 	defer linha.Close()
 
 	if linha.Next() {
+		var avatar, banner sql.NullString
+		var dataNascimento sql.NullTime
 		if erro := linha.Scan(&usuario.Id, &usuario.Nome,
-			&usuario.Email, &usuario.Nick, &usuario.CriadoEm); erro != nil {
+			&usuario.Email, &usuario.Nick, &usuario.CriadoEm, &avatar, &banner,
+			&usuario.Bio, &usuario.Localizacao, &usuario.Site, &dataNascimento,
+			&usuario.DataNascimentoPublica, &usuario.PublicacaoFixadaId, &usuario.MensagensApenasSeguidos, &usuario.Privado); erro != nil {
 			return usuario, erro
 		}
+		usuario.Avatar = ImagemPerfil(avatar, midia.TamanhosAvatar)
+		usuario.Banner = ImagemPerfil(banner, midia.TamanhosBanner)
+		if dataNascimento.Valid {
+			data := dataNascimento.Time.Format(time.DateOnly)
+			usuario.DataNascimento = &data
+		}
 		return usuario, nil
 	}
 
@@ -107,6 +98,49 @@ func (repository Usuarios) Atualizar(usuarioId uint64, usuario models.Usuario) e
 	return nil
 }
 
+// AtualizarPerfil grava todos os dados editáveis do perfil. O usuário já deve ter sido
+// carregado e alterado pelo chamador, que é quem trata a atualização parcial.
+// Uma conta que deixa de ser privada aceita todas as solicitações pendentes
+func (repository Usuarios) AtualizarPerfil(usuarioId uint64, usuario models.Usuario) error {
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return erro
+	}
+	defer tx.Rollback()
+
+	if _, erro := tx.Exec(`update usuarios set nome = ?, nick = ?, email = ?, bio = ?, localizacao = ?,
+		site = ?, dataNascimento = ?, dataNascimentoPublica = ?, publicacao_fixada = ?, mensagensApenasSeguidos = ?,
+		privado = ?
+		where id = ?`,
+		usuario.Nome, usuario.Nick, usuario.Email, usuario.Bio, usuario.Localizacao,
+		usuario.Site, usuario.DataNascimento, usuario.DataNascimentoPublica, usuario.PublicacaoFixadaId,
+		usuario.MensagensApenasSeguidos, usuario.Privado, usuarioId); erro != nil {
+		return erro
+	}
+
+	if !usuario.Privado {
+		solicitantes, erro := idsSolicitantes(tx, usuarioId)
+		if erro != nil {
+			return erro
+		}
+		for _, solicitanteId := range solicitantes {
+			if erro := registrarMudancaRelacao(tx, solicitanteId); erro != nil {
+				return erro
+			}
+		}
+
+		if _, erro := tx.Exec(`insert ignore into seguidores (usuario_id, seguidor_id)
+			select usuario_id, solicitante_id from solicitacoes_seguir where usuario_id = ?`, usuarioId); erro != nil {
+			return erro
+		}
+		if _, erro := tx.Exec("delete from solicitacoes_seguir where usuario_id = ?", usuarioId); erro != nil {
+			return erro
+		}
+	}
+
+	return tx.Commit()
+}
+
 func (repository Usuarios) Deletar(usuarioId uint64) error {
 	existe, erro := usuarioExiste(repository.db, usuarioId)
 
@@ -150,36 +184,187 @@ func (repository Usuarios) BuscarPorEmail(email string) (models.Usuario, error)
 	return usuario, sql.ErrNoRows
 }
 
+// AlternarSeguir desfaz o follow ou a solicitação pendente de seguidorId; se não houver nenhum,
+// segue usuarioId ou, se a conta for privada, pede para seguir
 func (repository Usuarios) AlternarSeguir(usuarioId uint64, seguidorId uint64) error {
-	resultado, erro := repository.db.Exec(
-		"delete from seguidores where usuario_id = ? and seguidor_id = ?",
-		usuarioId,
-		seguidorId,
-	)
+	tx, erro := repository.db.Begin()
 	if erro != nil {
 		return erro
 	}
+	defer tx.Rollback()
 
-	linhasAfetadas, erro := resultado.RowsAffected()
+	removido, erro := desfazerSeguir(tx, usuarioId, seguidorId)
 	if erro != nil {
 		return erro
 	}
 
-	if linhasAfetadas == 0 {
-		_, erro = repository.db.Exec(
-			"insert into seguidores (usuario_id, seguidor_id) values (?, ?)",
-			usuarioId,
-			seguidorId,
-		)
+	if !removido {
+		if erro := seguirOuSolicitar(tx, usuarioId, seguidorId); erro != nil {
+			return erro
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Seguir registra que seguidorId segue usuarioId ou, se a conta for privada, cria uma solicitação.
+// Seguir de novo não tem efeito nem notifica outra vez. Retorna ErrBloqueado se um dos dois bloqueou o outro
+func (repository Usuarios) Seguir(usuarioId uint64, seguidorId uint64) error {
+	tx, erro := repository.db.Begin()
+	if erro != nil {
 		return erro
 	}
+	defer tx.Rollback()
 
-	return nil
+	if erro := seguirOuSolicitar(tx, usuarioId, seguidorId); erro != nil {
+		return erro
+	}
+
+	return tx.Commit()
+}
+
+// DeixarDeSeguir desfaz o follow e cancela a solicitação pendente, se houver
+func (repository Usuarios) DeixarDeSeguir(usuarioId uint64, seguidorId uint64) error {
+	tx, erro := repository.db.Begin()
+	if erro != nil {
+		return erro
+	}
+	defer tx.Rollback()
+
+	if _, erro := desfazerSeguir(tx, usuarioId, seguidorId); erro != nil {
+		return erro
+	}
+
+	return tx.Commit()
+}
+
+// seguirOuSolicitar faz seguidorId seguir usuarioId ou, em contas privadas, pedir para seguir.
+// Só notifica quando cria o follow ou a solicitação
+func seguirOuSolicitar(exec executor, usuarioId uint64, seguidorId uint64) error {
+	if bloqueio, erro := bloqueados(exec, usuarioId, seguidorId); erro != nil || bloqueio {
+		if erro == nil {
+			erro = ErrBloqueado
+		}
+		return erro
+	}
+
+	privado, erro := existeLinha(exec, "select 1 from usuarios where id = ? and privado", usuarioId)
+	if erro != nil {
+		return erro
+	}
+
+	query, tipo := "insert ignore into seguidores (usuario_id, seguidor_id) values (?, ?)", models.NotificacaoSeguir
+	if privado {
+		seguindo, erro := existeLinha(exec, "select 1 from seguidores where usuario_id = ? and seguidor_id = ?", usuarioId, seguidorId)
+		if erro != nil || seguindo {
+			return erro
+		}
+		query, tipo = "insert ignore into solicitacoes_seguir (usuario_id, solicitante_id) values (?, ?)", models.NotificacaoSolicitacao
+	}
+
+	resultado, erro := exec.Exec(query, usuarioId, seguidorId)
+	if erro != nil {
+		return erro
+	}
+
+	if inseridas, erro := resultado.RowsAffected(); erro != nil || inseridas == 0 {
+		return erro
+	}
+
+	if !privado {
+		if erro := registrarMudancaRelacao(exec, seguidorId); erro != nil {
+			return erro
+		}
+	}
+
+	return notificar(exec, tipo, usuarioId, seguidorId, nil)
+}
+
+// desfazerSeguir remove o follow e a solicitação de seguidorId para usuarioId, com as notificações
+// ainda não lidas. Informa se havia algum dos dois
+func desfazerSeguir(exec executor, usuarioId uint64, seguidorId uint64) (bool, error) {
+	removido := false
+	for _, vinculo := range []struct{ query, tipo string }{
+		{"delete from seguidores where usuario_id = ? and seguidor_id = ?", models.NotificacaoSeguir},
+		{"delete from solicitacoes_seguir where usuario_id = ? and solicitante_id = ?", models.NotificacaoSolicitacao},
+	} {
+		resultado, erro := exec.Exec(vinculo.query, usuarioId, seguidorId)
+		if erro != nil {
+			return false, erro
+		}
+
+		removidas, erro := resultado.RowsAffected()
+		if erro != nil {
+			return false, erro
+		}
+		if removidas == 0 {
+			continue
+		}
+
+		removido = true
+		if erro := desfazerNotificacao(exec, vinculo.tipo, usuarioId, seguidorId, nil); erro != nil {
+			return false, erro
+		}
+		if vinculo.tipo == models.NotificacaoSeguir {
+			if erro := registrarMudancaRelacao(exec, seguidorId); erro != nil {
+				return false, erro
+			}
+		}
+	}
+
+	return removido, nil
+}
+
+// existeLinha informa se a consulta retorna alguma linha
+func existeLinha(exec executor, query string, args ...any) (bool, error) {
+	linhas, erro := exec.Query(query, args...)
+	if erro != nil {
+		return false, erro
+	}
+	defer linhas.Close()
+
+	return linhas.Next(), linhas.Err()
+}
+
+// EstadoSeguir informa se seguidorId segue ou pediu para seguir usuarioId e quantos seguidores usuarioId tem
+func (repository Usuarios) EstadoSeguir(usuarioId uint64, seguidorId uint64) (models.EstadoSeguir, error) {
+	estado := models.EstadoSeguir{UsuarioId: usuarioId}
+	erro := repository.db.QueryRow(`SELECT
+	       COUNT(*),
+	       COALESCE(SUM(seguidor_id = ?), 0) > 0,
+	       EXISTS (SELECT 1 FROM solicitacoes_seguir WHERE usuario_id = ? AND solicitante_id = ?)
+	   FROM seguidores WHERE usuario_id = ?`,
+		seguidorId, usuarioId, seguidorId, usuarioId).Scan(&estado.Seguidores, &estado.Seguindo, &estado.Solicitado)
+	return estado, erro
+}
+
+// Relacao informa, do ponto de vista de visitanteId, os follows, solicitações, bloqueios e silêncios
+// com usuarioId e quantos dos que o visitante segue também seguem usuarioId
+func (repository Usuarios) Relacao(usuarioId uint64, visitanteId uint64) (models.Relacao, error) {
+	relacao := models.Relacao{UsuarioId: usuarioId}
+	erro := repository.db.QueryRow(`SELECT
+	       EXISTS (SELECT 1 FROM seguidores WHERE usuario_id = ? AND seguidor_id = ?),
+	       EXISTS (SELECT 1 FROM seguidores WHERE usuario_id = ? AND seguidor_id = ?),
+	       EXISTS (SELECT 1 FROM solicitacoes_seguir WHERE usuario_id = ? AND solicitante_id = ?),
+	       EXISTS (SELECT 1 FROM solicitacoes_seguir WHERE usuario_id = ? AND solicitante_id = ?),
+	       EXISTS (SELECT 1 FROM bloqueios WHERE usuario_id = ? AND bloqueado_id = ?),
+	       EXISTS (SELECT 1 FROM bloqueios WHERE usuario_id = ? AND bloqueado_id = ?),
+	       EXISTS (SELECT 1 FROM silenciados WHERE usuario_id = ? AND silenciado_id = ?),
+	       (SELECT COUNT(*) FROM seguidores s1
+	        INNER JOIN seguidores s2 ON s2.seguidor_id = s1.usuario_id
+	        WHERE s1.seguidor_id = ? AND s2.usuario_id = ? AND `+semBloqueio("s1.usuario_id")+`)`,
+		usuarioId, visitanteId, visitanteId, usuarioId,
+		usuarioId, visitanteId, visitanteId, usuarioId,
+		visitanteId, usuarioId, usuarioId, visitanteId,
+		visitanteId, usuarioId,
+		visitanteId, usuarioId, visitanteId, visitanteId).Scan(&relacao.Seguindo, &relacao.SegueVoce, &relacao.Solicitado, &relacao.SolicitouVoce,
+		&relacao.Bloqueado, &relacao.BloqueiaVoce, &relacao.Silenciado, &relacao.SeguidoresEmComum)
+	return relacao, erro
 }
 
 func (repository Usuarios) BuscarSeguidores(usuarioId uint64) ([]models.Usuario, error) {
 	linhas, erro := repository.db.Query(`
-		select u.nome, u.email, u.nick from usuarios u
+		select u.nome, u.email, u.nick, u.avatar from usuarios u
 		inner join seguidores s on u.id = s.seguidor_id
 		where s.usuario_id = ?
 	`, usuarioId)
@@ -194,10 +379,12 @@ func (repository Usuarios) BuscarSeguidores(usuarioId uint64) ([]models.Usuario,
 
 	for linhas.Next() {
 		var usuario models.Usuario
-		if erro := linhas.Scan(&usuario.Nome, &usuario.Email, &usuario.Nick); erro != nil {
+		var avatar sql.NullString
+		if erro := linhas.Scan(&usuario.Nome, &usuario.Email, &usuario.Nick, &avatar); erro != nil {
 			return nil, erro
 		}
 
+		usuario.Avatar = ImagemPerfil(avatar, midia.TamanhosAvatar)
 		usuarios = append(usuarios, usuario)
 	}
 
@@ -206,7 +393,7 @@ func (repository Usuarios) BuscarSeguidores(usuarioId uint64) ([]models.Usuario,
 
 func (repository Usuarios) BuscarSeguindo(usuarioId uint64) ([]models.Usuario, error) {
 	linhas, erro := repository.db.Query(`
-		select u.nome, u.email, u.nick from usuarios u
+		select u.nome, u.email, u.nick, u.avatar from usuarios u
 		inner join seguidores s on u.id = s.usuario_id
 		where s.seguidor_id = ?
 	`, usuarioId)
@@ -221,16 +408,68 @@ func (repository Usuarios) BuscarSeguindo(usuarioId uint64) ([]models.Usuario, e
 
 	for linhas.Next() {
 		var usuario models.Usuario
-		if erro := linhas.Scan(&usuario.Nome, &usuario.Email, &usuario.Nick); erro != nil {
+		var avatar sql.NullString
+		if erro := linhas.Scan(&usuario.Nome, &usuario.Email, &usuario.Nick, &avatar); erro != nil {
 			return nil, erro
 		}
 
+		usuario.Avatar = ImagemPerfil(avatar, midia.TamanhosAvatar)
 		usuarios = append(usuarios, usuario)
 	}
 
 	return usuarios, nil
 }
 
+// BuscarSeguidoresEmComum lista quem visitanteId segue e também segue usuarioId, sem quem tem bloqueio com o visitante
+func (repository Usuarios) BuscarSeguidoresEmComum(usuarioId uint64, visitanteId uint64, paginacao models.Paginacao) ([]models.Usuario, error) {
+	linhas, erro := repository.db.Query(`select u.id, u.nome, u.nick, u.avatar from usuarios u
+		inner join seguidores s1 on s1.usuario_id = u.id
+		inner join seguidores s2 on s2.seguidor_id = u.id
+		where s1.seguidor_id = ? and s2.usuario_id = ? and `+semBloqueio("u.id")+`
+		order by u.nick, u.id
+		limit ? offset ?`,
+		visitanteId, usuarioId, visitanteId, visitanteId, paginacao.Limite, paginacao.Offset())
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	usuarios := []models.Usuario{}
+	for linhas.Next() {
+		var usuario models.Usuario
+		var avatar sql.NullString
+		if erro := linhas.Scan(&usuario.Id, &usuario.Nome, &usuario.Nick, &avatar); erro != nil {
+			return nil, erro
+		}
+
+		usuario.Avatar = ImagemPerfil(avatar, midia.TamanhosAvatar)
+		usuarios = append(usuarios, usuario)
+	}
+
+	return usuarios, linhas.Err()
+}
+
+// IdsSeguindo retorna os ids de quem o usuário segue, menos os que ele silenciou
+func (repository Usuarios) IdsSeguindo(usuarioId uint64) (map[uint64]bool, error) {
+	linhas, erro := repository.db.Query(`select s.usuario_id from seguidores s
+		where s.seguidor_id = ? and `+semSilenciado("s.usuario_id"), usuarioId, usuarioId)
+	if erro != nil {
+		return nil, erro
+	}
+	defer linhas.Close()
+
+	seguindo := make(map[uint64]bool)
+	for linhas.Next() {
+		var id uint64
+		if erro := linhas.Scan(&id); erro != nil {
+			return nil, erro
+		}
+		seguindo[id] = true
+	}
+
+	return seguindo, linhas.Err()
+}
+
 func (repository Usuarios) BuscarSenhaPorId(usuarioId uint64) (string, error) {
 	var senha string
 	linha, erro := repository.db.Query("select senha from usuarios where id = ?", usuarioId)
@@ -265,6 +504,11 @@ func (repository Usuarios) AlterarSenha(usuarioId uint64, novaSenha string) erro
 	return nil
 }
 
+// UsuarioExiste informa se há um usuário com o id
+func (repository Usuarios) UsuarioExiste(usuarioId uint64) (bool, error) {
+	return usuarioExiste(repository.db, usuarioId)
+}
+
 func usuarioExiste(db *sql.DB, id uint64) (bool, error) {
 	var exists bool
 	err := db.QueryRow("select 1 from usuarios where id = ?", id).Scan(&exists)
@@ -277,3 +521,42 @@ func usuarioExiste(db *sql.DB, id uint64) (bool, error) {
 	}
 	return true, nil // existe
 }
+
+// BuscarImagens retorna as chaves do avatar e do banner do usuário, inválidas quando não há imagem
+func (repository Usuarios) BuscarImagens(usuarioId uint64) (avatar sql.NullString, banner sql.NullString, erro error) {
+	erro = repository.db.QueryRow("select avatar, banner from usuarios where id = ?", usuarioId).Scan(&avatar, &banner)
+	return avatar, banner, erro
+}
+
+// AtualizarAvatar grava a chave do novo avatar. Chave nil remove o avatar
+func (repository Usuarios) AtualizarAvatar(usuarioId uint64, chave *string) error {
+	_, erro := repository.db.Exec("update usuarios set avatar = ? where id = ?", chave, usuarioId)
+	return erro
+}
+
+// AtualizarBanner grava a chave do novo banner. Chave nil remove o banner
+func (repository Usuarios) AtualizarBanner(usuarioId uint64, chave *string) error {
+	_, erro := repository.db.Exec("update usuarios set banner = ? where id = ?", chave, usuarioId)
+	return erro
+}
+
+// ImagemPerfil monta as URLs assinadas de cada tamanho a partir da chave guardada no banco
+func ImagemPerfil(chave sql.NullString, tamanhos []midia.Tamanho) *models.ImagemPerfil {
+	if !chave.Valid {
+		return nil
+	}
+
+	imagem := &models.ImagemPerfil{}
+	for _, tamanho := range tamanhos {
+		url := midia.URLAssinada(midia.ChaveVariante(chave.String, tamanho.Nome))
+		switch tamanho.Nome {
+		case "pequeno":
+			imagem.Pequeno = url
+		case "medio":
+			imagem.Medio = url
+		case "grande":
+			imagem.Grande = url
+		}
+	}
+	return imagem
+}
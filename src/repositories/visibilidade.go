@@ -0,0 +1,34 @@
+package repositories
+
+// publicacaoVisivel é a condição para o visitante ver a publicação p, cujo autor é u. O autor sempre vê.
+// Publicações para mencionados só aparecem para quem foi mencionado; as para seguidores, e qualquer
+// uma de conta privada, só para seguidores aceitos. Nenhuma aparece quando há bloqueio entre o autor
+// e o visitante. Os parâmetros vêm de argsVisivel; com visitante 0 sobra só o que é aberto a todos
+const publicacaoVisivel = `(p.autor_id = ?
+	       OR (p.visibilidade = 'mencionados'
+	           AND EXISTS (SELECT 1 FROM mencoes mv WHERE mv.publicacao_id = p.id AND mv.usuario_id = ?))
+	       OR (p.visibilidade <> 'mencionados'
+	           AND ((p.visibilidade = 'publica' AND NOT u.privado)
+	                OR EXISTS (SELECT 1 FROM seguidores sv WHERE sv.usuario_id = p.autor_id AND sv.seguidor_id = ?))))
+	   AND NOT EXISTS (SELECT 1 FROM bloqueios b
+	       WHERE (b.usuario_id = ? AND b.bloqueado_id = p.autor_id)
+	          OR (b.usuario_id = p.autor_id AND b.bloqueado_id = ?))`
+
+// argsVisivel são os parâmetros de publicacaoVisivel
+func argsVisivel(visitanteId uint64) []any {
+	return []any{visitanteId, visitanteId, visitanteId, visitanteId, visitanteId}
+}
+
+// VisivelPara informa se a publicação publicada existe para o visitante
+func (repository Publicacoes) VisivelPara(publicacaoId uint64, visitanteId uint64) (bool, error) {
+	linhas, erro := repository.db.Query(`SELECT 1 FROM publicacoes p
+	   INNER JOIN usuarios u ON u.id = p.autor_id
+	   WHERE p.id = ? AND p.status = 'publicada' AND `+publicacaoVisivel,
+		append([]any{publicacaoId}, argsVisivel(visitanteId)...)...)
+	if erro != nil {
+		return false, erro
+	}
+	defer linhas.Close()
+
+	return linhas.Next(), linhas.Err()
+}
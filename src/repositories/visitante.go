@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"api/src/models"
+	"database/sql"
+)
+
+// carregarEstadoVisitante preenche o que cada publicação representa para o visitante: se ele é o autor,
+// se segue o autor, se curtiu, reagiu (e se a publicação foi editada depois), republicou ou salvou.
+// São poucas consultas para a lista toda, cada uma limitada às publicações ou aos autores da lista.
+// Sem visitante, tudo fica zerado
+func carregarEstadoVisitante(db *sql.DB, publicacoes []models.Publicacao, visitanteId uint64) error {
+	if len(publicacoes) == 0 || visitanteId == 0 {
+		return nil
+	}
+
+	ids := idsPublicacoes(publicacoes)
+	argsIds := append([]any{visitanteId}, ids...)
+
+	reacoes := make(map[uint64]models.Reacao)
+	if erro := percorrerLinhas(db, func(linhas *sql.Rows) error {
+		var publicacaoId uint64
+		var reacao models.Reacao
+		if erro := linhas.Scan(&publicacaoId, &reacao.Tipo, &reacao.CriadaEm); erro != nil {
+			return erro
+		}
+		reacoes[publicacaoId] = reacao
+		return nil
+	}, `select publicacao_id, tipo, criadaEm from reacoes
+		where usuario_id = ? and publicacao_id in (`+placeholders(len(ids))+`)`, argsIds...); erro != nil {
+		return erro
+	}
+
+	republicadas, erro := consultarIds(db, `select publicacao_id from republicacoes
+		where usuario_id = ? and publicacao_id in (`+placeholders(len(ids))+`)`, argsIds...)
+	if erro != nil {
+		return erro
+	}
+
+	salvas, erro := consultarIds(db, `select publicacao_id from salvos
+		where usuario_id = ? and publicacao_id in (`+placeholders(len(ids))+`)`, argsIds...)
+	if erro != nil {
+		return erro
+	}
+
+	autores := []any{visitanteId}
+	vistos := make(map[uint64]bool)
+	for _, publicacao := range publicacoes {
+		if !vistos[publicacao.AutorId] {
+			vistos[publicacao.AutorId] = true
+			autores = append(autores, publicacao.AutorId)
+		}
+	}
+
+	seguidos, erro := consultarIds(db, `select usuario_id from seguidores
+		where seguidor_id = ? and usuario_id in (`+placeholders(len(autores)-1)+`)`, autores...)
+	if erro != nil {
+		return erro
+	}
+
+	for i := range publicacoes {
+		publicacao := &publicacoes[i]
+		publicacao.Minha = publicacao.AutorId == visitanteId
+		publicacao.SigoAutor = seguidos[publicacao.AutorId]
+		if reacao, reagiu := reacoes[publicacao.Id]; reagiu {
+			publicacao.MinhaReacao = reacao.Tipo
+			publicacao.EditadaAposMinhaReacao = publicacao.EditadaEm != nil && publicacao.EditadaEm.After(reacao.CriadaEm)
+		}
+		publicacao.CurtidoPorMim = publicacao.MinhaReacao == models.ReacaoCurtir
+		publicacao.RepublicadoPorMim = republicadas[publicacao.Id]
+		publicacao.Salvo = salvas[publicacao.Id]
+	}
+
+	return nil
+}
+
+// consultarIds retorna como conjunto os ids da primeira coluna da consulta
+func consultarIds(db *sql.DB, query string, args ...any) (map[uint64]bool, error) {
+	ids := make(map[uint64]bool)
+	erro := percorrerLinhas(db, func(linhas *sql.Rows) error {
+		var id uint64
+		if erro := linhas.Scan(&id); erro != nil {
+			return erro
+		}
+		ids[id] = true
+		return nil
+	}, query, args...)
+	return ids, erro
+}
+
+// percorrerLinhas roda a consulta e entrega cada linha para ler
+func percorrerLinhas(db *sql.DB, ler func(*sql.Rows) error, query string, args ...any) error {
+	linhas, erro := db.Query(query, args...)
+	if erro != nil {
+		return erro
+	}
+	defer linhas.Close()
+
+	for linhas.Next() {
+		if erro := ler(linhas); erro != nil {
+			return erro
+		}
+	}
+
+	return linhas.Err()
+}
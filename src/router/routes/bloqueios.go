@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"api/src/controllers"
+	"net/http"
+)
+
+var rotasBloqueios = []Rota{
+	{
+		URI:                "/usuarios/{id}/bloqueio",
+		Metodo:             http.MethodPut,
+		Funcao:             controllers.BloquearUsuario,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/usuarios/{id}/bloqueio",
+		Metodo:             http.MethodDelete,
+		Funcao:             controllers.DesbloquearUsuario,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/usuarios/{id}/silencio",
+		Metodo:             http.MethodPut,
+		Funcao:             controllers.SilenciarUsuario,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/usuarios/{id}/silencio",
+		Metodo:             http.MethodDelete,
+		Funcao:             controllers.DessilenciarUsuario,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/bloqueados",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarBloqueados,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/silenciados",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarSilenciados,
+		RequerAutenticacao: true,
+	},
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"api/src/controllers"
+	"net/http"
+)
+
+var rotasBusca = []Rota{
+	{
+		URI:                "/busca/publicacoes",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarPublicacoesPorTexto,
+		RequerAutenticacao: true,
+	},
+}
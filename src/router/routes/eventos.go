@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"api/src/controllers"
+	"net/http"
+)
+
+// A autenticação é feita pelo próprio controller, que também aceita o token na URL
+var rotaEventos = Rota{
+	URI:                "/eventos",
+	Metodo:             http.MethodGet,
+	Funcao:             controllers.AcompanharEventos,
+	RequerAutenticacao: false,
+}
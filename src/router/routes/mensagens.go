@@ -0,0 +1,63 @@
+package routes
+
+import (
+	"api/src/controllers"
+	"net/http"
+)
+
+var rotasMensagens = []Rota{
+	{
+		URI:                "/conversas",
+		Metodo:             http.MethodPost,
+		Funcao:             controllers.CriarConversa,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/conversas",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarConversas,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/conversas/nao-lidas",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.ContarMensagensNaoLidas,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/conversas/{id}",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarConversaDireta,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/conversas/{id}",
+		Metodo:             http.MethodDelete,
+		Funcao:             controllers.ApagarConversa,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/conversas/{id}/mensagens",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarMensagens,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/conversas/{id}/mensagens",
+		Metodo:             http.MethodPost,
+		Funcao:             controllers.EnviarMensagem,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/conversas/{id}/lida",
+		Metodo:             http.MethodPut,
+		Funcao:             controllers.MarcarConversaLida,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/conversas/{id}/mensagens/{mensagemId}",
+		Metodo:             http.MethodDelete,
+		Funcao:             controllers.ApagarMensagem,
+		RequerAutenticacao: true,
+	},
+}
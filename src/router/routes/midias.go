@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"api/src/controllers"
+	"net/http"
+)
+
+// A rota de mídia não usa token porque é acessada por tags <img>;
+// a autorização vem da assinatura na própria URL
+var rotaMidias = Rota{
+	URI:                "/midias/{chave:.+}",
+	Metodo:             http.MethodGet,
+	Funcao:             controllers.BaixarMidia,
+	RequerAutenticacao: false,
+}
@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"api/src/controllers"
+	"net/http"
+)
+
+var rotasNotificacoes = []Rota{
+	{
+		URI:                "/notificacoes",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarNotificacoes,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/notificacoes/nao-lidas",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.ContarNotificacoesNaoLidas,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/notificacoes/lidas",
+		Metodo:             http.MethodPut,
+		Funcao:             controllers.MarcarTodasNotificacoesLidas,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/notificacoes/{id}/lida",
+		Metodo:             http.MethodPut,
+		Funcao:             controllers.MarcarNotificacaoLida,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/notificacoes/preferencias",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarPreferenciasNotificacao,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/notificacoes/preferencias",
+		Metodo:             http.MethodPut,
+		Funcao:             controllers.AtualizarPreferenciasNotificacao,
+		RequerAutenticacao: true,
+	},
+}
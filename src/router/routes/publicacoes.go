@@ -48,4 +48,94 @@ var rotasPublicacoes = []Rota{
 		Funcao:             controllers.CurtirPublicacao,
 		RequerAutenticacao: true,
 	},
+	{
+		URI:                "/publicacoes/{id}/comentarios",
+		Metodo:             http.MethodPost,
+		Funcao:             controllers.CriarComentario,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/publicacoes/{id}/comentarios",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarComentarios,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/publicacoes/{id}/comentarios/{comentarioId}",
+		Metodo:             http.MethodPut,
+		Funcao:             controllers.AtualizarComentario,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/publicacoes/{id}/comentarios/{comentarioId}",
+		Metodo:             http.MethodDelete,
+		Funcao:             controllers.DeletarComentario,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/publicacoes/{id}/conversa",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarConversa,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/publicacoes/{id}/republicar",
+		Metodo:             http.MethodPost,
+		Funcao:             controllers.RepublicarPublicacao,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/publicacoes/{id}/republicar",
+		Metodo:             http.MethodDelete,
+		Funcao:             controllers.DesfazerRepublicacao,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/publicacoes/{id}/reacao",
+		Metodo:             http.MethodPut,
+		Funcao:             controllers.ReagirPublicacao,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/publicacoes/{id}/reacao",
+		Metodo:             http.MethodDelete,
+		Funcao:             controllers.RemoverReacao,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/publicacoes/{id}/reacoes",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarReacoes,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/publicacoes/{id}/curtida",
+		Metodo:             http.MethodPut,
+		Funcao:             controllers.AdicionarCurtida,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/publicacoes/{id}/curtida",
+		Metodo:             http.MethodDelete,
+		Funcao:             controllers.RemoverCurtida,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/publicacoes/{id}/revisoes",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarRevisoes,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/publicacoes/{id}/anexos",
+		Metodo:             http.MethodPost,
+		Funcao:             controllers.EnviarAnexo,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/publicacoes/{id}/anexos/{anexoId}",
+		Metodo:             http.MethodDelete,
+		Funcao:             controllers.DeletarAnexo,
+		RequerAutenticacao: true,
+	},
 }
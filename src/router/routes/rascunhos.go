@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"api/src/controllers"
+	"net/http"
+)
+
+var rotasRascunhos = []Rota{
+	{
+		URI:                "/rascunhos",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarRascunhos,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/rascunhos/{id}",
+		Metodo:             http.MethodPut,
+		Funcao:             controllers.AtualizarRascunho,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/rascunhos/{id}/publicar",
+		Metodo:             http.MethodPost,
+		Funcao:             controllers.PublicarRascunho,
+		RequerAutenticacao: true,
+	},
+}
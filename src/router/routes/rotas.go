@@ -23,6 +23,16 @@ func Configurar(r *mux.Router) *mux.Router {
 	rotas := rotasUsuarios
 	rotas = append(rotas, loginRoute)
 	rotas = append(rotas, rotasPublicacoes...) //Os 3 pontos em sequencia é para informar que ta passando uma lista como append
+	rotas = append(rotas, rotasRascunhos...)
+	rotas = append(rotas, rotasTags...)
+	rotas = append(rotas, rotasNotificacoes...)
+	rotas = append(rotas, rotasMensagens...)
+	rotas = append(rotas, rotasBloqueios...)
+	rotas = append(rotas, rotasSolicitacoes...)
+	rotas = append(rotas, rotasSalvos...)
+	rotas = append(rotas, rotasBusca...)
+	rotas = append(rotas, rotaMidias)
+	rotas = append(rotas, rotaEventos)
 
 	for _, rota := range rotas {
 		if rota.RequerAutenticacao {
@@ -0,0 +1,51 @@
+package routes
+
+import (
+	"api/src/controllers"
+	"net/http"
+)
+
+var rotasSalvos = []Rota{
+	{
+		URI:                "/publicacoes/{id}/salvo",
+		Metodo:             http.MethodPut,
+		Funcao:             controllers.SalvarPublicacao,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/publicacoes/{id}/salvo",
+		Metodo:             http.MethodDelete,
+		Funcao:             controllers.RemoverSalvo,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/salvos",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarSalvos,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/colecoes",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarColecoes,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/colecoes",
+		Metodo:             http.MethodPost,
+		Funcao:             controllers.CriarColecao,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/colecoes/{id}",
+		Metodo:             http.MethodPut,
+		Funcao:             controllers.RenomearColecao,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/colecoes/{id}",
+		Metodo:             http.MethodDelete,
+		Funcao:             controllers.DeletarColecao,
+		RequerAutenticacao: true,
+	},
+}
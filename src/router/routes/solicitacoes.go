@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"api/src/controllers"
+	"net/http"
+)
+
+var rotasSolicitacoes = []Rota{
+	{
+		URI:                "/solicitacoes",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarSolicitacoes,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/solicitacoes/{id}",
+		Metodo:             http.MethodPut,
+		Funcao:             controllers.AceitarSolicitacao,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/solicitacoes/{id}",
+		Metodo:             http.MethodDelete,
+		Funcao:             controllers.RecusarSolicitacao,
+		RequerAutenticacao: true,
+	},
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"api/src/controllers"
+	"net/http"
+)
+
+var rotasTags = []Rota{
+	{
+		URI:                "/tags/trending",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarTagsEmAlta,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/tags/{tag}/publicacoes",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarPublicacoesTag,
+		RequerAutenticacao: true,
+	},
+}
@@ -18,6 +18,25 @@ var rotasUsuarios = []Rota{
 		Funcao:             controllers.ListarUsuarios,
 		RequerAutenticacao: true,
 	},
+	// Rotas fixas em /usuarios/... precisam vir antes de /usuarios/{id}, senão o mux as confunde com um id
+	{
+		URI:                "/usuarios/autocompletar",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.AutocompletarUsuarios,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/usuarios/sugestoes",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarSugestoes,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/usuarios/sugestoes/{id}",
+		Metodo:             http.MethodDelete,
+		Funcao:             controllers.DispensarSugestao,
+		RequerAutenticacao: true,
+	},
 	{
 		URI:                "/usuarios/{id}",
 		Metodo:             http.MethodGet,
@@ -30,6 +49,12 @@ var rotasUsuarios = []Rota{
 		Funcao:             controllers.AtualizarUsuario,
 		RequerAutenticacao: true,
 	},
+	{
+		URI:                "/usuarios/{id}",
+		Metodo:             http.MethodPatch,
+		Funcao:             controllers.AtualizarPerfil,
+		RequerAutenticacao: true,
+	},
 	{
 		URI:                "/usuarios/{id}",
 		Metodo:             http.MethodDelete,
@@ -42,6 +67,18 @@ var rotasUsuarios = []Rota{
 		Funcao:             controllers.AlternarSeguirUsuario,
 		RequerAutenticacao: true,
 	},
+	{
+		URI:                "/usuarios/{id}/seguir",
+		Metodo:             http.MethodPut,
+		Funcao:             controllers.SeguirUsuario,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/usuarios/{id}/seguir",
+		Metodo:             http.MethodDelete,
+		Funcao:             controllers.DeixarDeSeguirUsuario,
+		RequerAutenticacao: true,
+	},
 	{
 		URI:                "/usuarios/{id}/seguidores",
 		Metodo:             http.MethodGet,
@@ -54,6 +91,42 @@ var rotasUsuarios = []Rota{
 		Funcao:             controllers.BuscarSeguindo,
 		RequerAutenticacao: true,
 	},
+	{
+		URI:                "/usuarios/{id}/seguidores-em-comum",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarSeguidoresEmComum,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/usuarios/{id}/relacao",
+		Metodo:             http.MethodGet,
+		Funcao:             controllers.BuscarRelacao,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/usuarios/{id}/avatar",
+		Metodo:             http.MethodPut,
+		Funcao:             controllers.EnviarAvatar,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/usuarios/{id}/avatar",
+		Metodo:             http.MethodDelete,
+		Funcao:             controllers.RemoverAvatar,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/usuarios/{id}/banner",
+		Metodo:             http.MethodPut,
+		Funcao:             controllers.EnviarBanner,
+		RequerAutenticacao: true,
+	},
+	{
+		URI:                "/usuarios/{id}/banner",
+		Metodo:             http.MethodDelete,
+		Funcao:             controllers.RemoverBanner,
+		RequerAutenticacao: true,
+	},
 	{
 		URI:                "/usuarios/alterar_senha",
 		Metodo:             http.MethodPost,
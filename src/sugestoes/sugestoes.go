@@ -0,0 +1,46 @@
+// Package sugestoes recalcula periodicamente as contas sugeridas para cada usuário seguir
+package sugestoes
+
+import (
+	"api/src/config"
+	"api/src/database"
+	"api/src/repositories"
+	"context"
+	"log"
+	"time"
+)
+
+// Iniciar recalcula as sugestões a cada intervalo até o contexto ser cancelado. A primeira
+// rodada é imediata, para a API não subir com sugestões de um cálculo antigo
+func Iniciar(ctx context.Context, intervalo time.Duration) {
+	ticker := time.NewTicker(intervalo)
+	defer ticker.Stop()
+
+	for {
+		calcular()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func calcular() {
+	db, erro := database.Conectar()
+	if erro != nil {
+		log.Printf("sugestoes: erro ao conectar no banco: %v", erro)
+		return
+	}
+	defer db.Close()
+
+	desde := time.Now().Add(-config.SugestoesJanelaAtividade)
+	geradas, erro := repositories.NewUsuariosRepo(db).CalcularSugestoes(desde)
+	if erro != nil {
+		log.Printf("sugestoes: erro ao calcular as sugestões: %v", erro)
+		return
+	}
+
+	log.Printf("sugestoes: %d sugestões calculadas", geradas)
+}